@@ -28,22 +28,67 @@ minimizes the resulting leftover area. There is no real reason to maintain a
 tree - in fact it's just redundant structuring. We could as well have two
 lists - one for free rectangles and one for used rectangles. This method would
 be faster and might even achieve a considerably better occupancy rate.
+
+All output of this package is deterministic: the same sequence of operations
+on a packer always produces byte-identical output. This covers the operation
+log, DebugHandler, WriteCSV, WriteGoSource, WriteCutList, Report and the text
+and JSON forms of Rect. New exporters must keep it that way, e.g. never
+iterate over maps.
 */
 package binpacker
 
-import "errors"
+import (
+	"errors"
+	"io"
+	"sort"
+	"sync/atomic"
+	"unsafe"
+)
 
 func New(width, height int) *Packer {
-	return &Packer{
+	p := &Packer{
 		root:      node{Rect: Rect{Width: width, Height: height}},
 		binWidth:  width,
 		binHeight: height,
 	}
+	p.publish()
+	return p
 }
 
 type Packer struct {
 	root                node
 	binWidth, binHeight int
+	inserts, failures   int
+	failStreak          int // failures since the last successful insert
+	removals            int
+	log                 io.Writer
+	aspectW, aspectH    int
+	growth              GrowthPolicy
+	items               []item // all inserted rectangles, in insertion order
+	padding             int
+	alignment           int
+	maxW, maxH          int
+	maxOccupancy        int // in percent, 0 means no limit
+	uvInset             float64
+	originX, originY    int
+	yUp                 bool
+	trim                [4]int // left, top, right, bottom
+	defects             []Rect
+	spacing             []spacingRule
+	// rects holds the same rectangles as items. It is only ever appended to
+	// or replaced, never modified, so it can be shared with snapshots.
+	rects    []Rect
+	snapshot atomic.Value // *Layout
+}
+
+// item is an inserted rectangle. It occupies a larger region of the bin which
+// includes its padding and alignment.
+type item struct {
+	Rect
+	region   Rect
+	tags     []string
+	padding  int  // as requested, before alignment
+	unpadded Edge // sides without padding, see InsertAtEdge
 }
 
 type node struct {
@@ -54,40 +99,215 @@ type node struct {
 type Rect struct{ X, Y, Width, Height int }
 
 // Enlarge will mark the previous space as completely occupied and insert the
-// new area right and down of the existing area.
+// new area right and down of the existing area. The occupied region is exactly
+// the previous bin, so Occupancy and Walk report the old area as used and the
+// new area as free.
 func (p *Packer) Enlarge(newWidth, newHeight int) error {
 	if newWidth < p.binWidth || newHeight < p.binHeight {
 		return errors.New("enlarge: new size is smaller")
 	}
+	if w, h := p.limitSize(newWidth, newHeight); w != newWidth || h != newHeight {
+		return ErrMaxSize
+	}
+	if err := p.writeLog("enlarge", newWidth, newHeight); err != nil {
+		return err
+	}
+
+	p.root = p.enlargedRoot(newWidth, newHeight)
+	p.binWidth = newWidth
+	p.binHeight = newHeight
+	p.trim = [4]int{} // the new stock is not trimmed
+	p.publish()
 
-	p.root = node{
-		Rect: Rect{X: 0, Y: 0, Width: newWidth, Height: newHeight},
+	return nil
+}
+
+// ShrinkTo repacks all inserted rectangles into a new bin of the given size,
+// which is typically smaller than the current one. It returns where each
+// rectangle was moved to. If the rectangles do not all fit, ErrNoMoreSpace is
+// returned and the packer is left unchanged.
+func (p *Packer) ShrinkTo(width, height int) (map[Rect]Rect, error) {
+	root := node{Rect: p.trimmed(width, height)}
+	items, ok := repack(&root, p.items, p.defects)
+	if !ok {
+		return nil, ErrNoMoreSpace
+	}
+	oldRects := make([]Rect, len(p.items))
+	for i := range p.items {
+		oldRects[i] = p.flip(p.items[i].Rect)
+	}
+
+	if err := p.writeLog("shrink", width, height); err != nil {
+		return nil, err
+	}
+	p.root = root
+	p.binWidth, p.binHeight = width, height
+	p.items = items
+	p.rects = make([]Rect, len(items))
+	mapping := make(map[Rect]Rect, len(items))
+	for i := range items {
+		p.rects[i] = items[i].Rect
+		mapping[oldRects[i]] = p.flip(items[i].Rect)
+	}
+	p.publish()
+	return mapping, nil
+}
+
+// repack places the regions of all items in the free tree root and returns the
+// moved items in the same order. It returns false if they do not all fit.
+func repack(root *node, items []item, defects []Rect) ([]item, bool) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return packsBefore(items[order[i]].region, items[order[j]].region)
+	})
+
+	moved := make([]item, len(items))
+	for _, i := range order {
+		old := items[i]
+		n, right, bottom := findFree(root, old.region.Width, old.region.Height, defects)
+		if n == nil {
+			return nil, false
+		}
+		split(n, old.region.Width, old.region.Height, right, bottom)
+		moved[i] = old
+		moved[i].region = n.Rect
+		moved[i].X = n.X + old.X - old.region.X
+		moved[i].Y = n.Y + old.Y - old.region.Y
+	}
+	return moved, true
+}
+
+// packsBefore is the order in which regions are repacked. The tallest regions
+// go first, this usually wastes the least space.
+func packsBefore(a, b Rect) bool {
+	if a.Height != b.Height {
+		return a.Height > b.Height
+	}
+	return a.Width > b.Width
+}
+
+// enlargedRoot returns the tree that Enlarge(newWidth, newHeight) creates.
+func (p *Packer) enlargedRoot(newWidth, newHeight int) node {
+	return node{
+		Rect: Rect{X: p.originX, Y: p.originY, Width: p.binWidth, Height: p.binHeight},
 		left: &node{Rect: Rect{
-			X:      0,
-			Y:      p.binHeight,
+			X:      p.originX,
+			Y:      p.originY + p.binHeight,
 			Width:  newWidth,
 			Height: newHeight - p.binHeight,
 		}},
 		right: &node{Rect: Rect{
-			X:      p.binWidth,
-			Y:      0,
+			X:      p.originX + p.binWidth,
+			Y:      p.originY,
 			Width:  newWidth - p.binWidth,
 			Height: p.binHeight,
 		}},
 	}
+}
 
-	p.binWidth = newWidth
-	p.binHeight = newHeight
+// Insert places a rectangle of the given size in the bin and returns its
+// position. The rectangle is surrounded by the padding set with SetPadding.
+func (p *Packer) Insert(width, height int) (Rect, error) {
+	if err := p.writeLog("insert", width, height); err != nil {
+		return Rect{}, err
+	}
+	return p.insert(width, height, p.padding, nil)
+}
+
+// InsertPadded works like Insert but overrides the packer's padding for this
+// rectangle.
+func (p *Packer) InsertPadded(width, height, padding int) (Rect, error) {
+	if err := p.writeLog("insert", width, height, padding); err != nil {
+		return Rect{}, err
+	}
+	return p.insert(width, height, padding, nil)
+}
+
+// SetPadding sets the number of free pixels that Insert keeps around each
+// rectangle on all sides. Padding of neighboring rectangles adds up. The
+// default is no padding.
+func (p *Packer) SetPadding(padding int) error {
+	if err := p.writeLog("padding", padding); err != nil {
+		return err
+	}
+	p.padding = padding
+	return nil
+}
+
+// BlockSize is the side length of the pixel blocks of BC and ETC compressed
+// textures. Use SetAlignment(BlockSize) when packing compressed atlases.
+const BlockSize = 4
 
+// SetAlignment makes Insert place rectangles at multiples of n and round their
+// sizes and padding up to multiples of n. This way no two rectangles share a
+// block of n x n pixels, e.g. for block compressed textures, see BlockSize.
+// The bin size should be a multiple of n as well. n <= 1 turns alignment off.
+func (p *Packer) SetAlignment(n int) error {
+	if err := p.writeLog("align", n); err != nil {
+		return err
+	}
+	p.alignment = n
 	return nil
 }
 
-func (p *Packer) Insert(width, height int) (Rect, error) {
-	n, err := insert(&p.root, width, height)
+// placer chooses a free leaf of the tree for a region of the given size and
+// the corner of the leaf to put the region in. It returns nil if there is no
+// suitable leaf.
+type placer func(width, height int) (leaf *node, right, bottom bool)
+
+// insert places an item in the bin. The region for the item with its padding
+// is placed by place, or by the default first-fit strategy if place is nil.
+func (p *Packer) insert(width, height, padding int, place placer) (Rect, error) {
+	return p.insertUnpadded(width, height, padding, 0, place)
+}
+
+// insertUnpadded works like insert but leaves out the padding on the given
+// sides of the item.
+func (p *Packer) insertUnpadded(width, height, padding int, unpadded Edge, place placer) (Rect, error) {
+	regionW, regionH, padX, padY := p.unpaddedRegionSize(width, height, padding, unpadded)
+	if p.exceedsMaxOccupancy(regionW * regionH) {
+		p.failures++
+		p.failStreak++
+		return Rect{}, ErrMaxOccupancy
+	}
+	if place == nil && len(p.defects) > 0 {
+		place = func(w, h int) (*node, bool, bool) {
+			return findFree(&p.root, w, h, p.defects)
+		}
+	}
+	var n *node
+	err := ErrNoMoreSpace
+	if place == nil {
+		n, err = insert(&p.root, regionW, regionH)
+	} else if leaf, right, bottom := place(regionW, regionH); leaf != nil {
+		split(leaf, regionW, regionH, right, bottom)
+		n, err = leaf, nil
+	}
 	if err != nil {
+		p.failures++
+		p.failStreak++
 		return Rect{}, err
 	}
-	return n.Rect, nil
+	it := item{
+		Rect: Rect{
+			X:      n.X + padX,
+			Y:      n.Y + padY,
+			Width:  width,
+			Height: height,
+		},
+		region:   n.Rect,
+		padding:  padding,
+		unpadded: unpadded,
+	}
+	p.inserts++
+	p.failStreak = 0
+	p.items = append(p.items, it)
+	p.rects = append(p.rects, it.Rect)
+	p.publish()
+	return p.flip(it.Rect), nil
 }
 
 var ErrNoMoreSpace = errors.New("insert: no more space in bin")
@@ -114,34 +334,53 @@ func insert(n *node, width, height int) (*node, error) {
 		return nil, ErrNoMoreSpace
 	}
 
+	split(n, width, height, false, false)
+	return n, nil
+}
+
+// split places a rectangle of the given size in a corner of the leaf n, the
+// top-left corner by default, and creates the leaf's children for the
+// remaining free space.
+func split(n *node, width, height int, right, bottom bool) {
 	// the new cell will fit, split the remaining space along the shorter axis,
 	// that is probably more optimal.
 	restW, restH := n.Width-width, n.Height-height
 
+	// (x, y) is the new cell's position, besideX is where the free space
+	// next to it starts and belowY where the free space below (or above) it
+	// starts.
+	x, y, besideX, belowY := n.X, n.Y, n.X+width, n.Y+height
+	if right {
+		x, besideX = n.X+restW, n.X
+	}
+	if bottom {
+		y, belowY = n.Y+restH, n.Y
+	}
+
 	if restW < restH {
 		// split the remaining space horizontally
 		n.left = &node{Rect: Rect{
-			X:      n.X + width,
-			Y:      n.Y,
+			X:      besideX,
+			Y:      y,
 			Width:  restW,
 			Height: height,
 		}}
 		n.right = &node{Rect: Rect{
 			X:      n.X,
-			Y:      n.Y + height,
+			Y:      belowY,
 			Width:  n.Width,
 			Height: restH,
 		}}
 	} else {
 		// split the remaining space vertically
 		n.left = &node{Rect: Rect{
-			X:      n.X,
-			Y:      n.Y + height,
+			X:      x,
+			Y:      belowY,
 			Width:  width,
 			Height: restH,
 		}}
 		n.right = &node{Rect: Rect{
-			X:      n.X + width,
+			X:      besideX,
 			Y:      n.Y,
 			Width:  restW,
 			Height: n.Height,
@@ -157,8 +396,23 @@ func insert(n *node, width, height int) (*node, error) {
 	// This node is now a non-leaf, so shrink its area - it now denotes
 	// *occupied* space instead of free space. Its children spawn the resulting
 	// area of free space.
+	n.X, n.Y = x, y
 	n.Width, n.Height = width, height
-	return n, nil
+}
+
+// leaves calls f for all leaves of the tree, in the order that insert searches
+// them, until f returns false.
+func leaves(n *node, f func(leaf *node) bool) bool {
+	if n.left == nil && n.right == nil {
+		return f(n)
+	}
+	if n.left != nil && !leaves(n.left, f) {
+		return false
+	}
+	if n.right != nil && !leaves(n.right, f) {
+		return false
+	}
+	return true
 }
 
 func (p *Packer) Occupancy() float64 {
@@ -179,3 +433,218 @@ func usedArea(n *node) int {
 	// this is a leaf node and does not constitute to the total surface area
 	return 0
 }
+
+// MemoryFootprint returns the approximate number of bytes used by the packer.
+// Every successful Insert adds two nodes to the internal tree and a record of
+// the rectangle, so memory grows linearly with the number of packed
+// rectangles, plus the size of their tags and of the defects. Enlarge drops
+// the old tree, but the records of all rectangles, their tags and the defects
+// are kept, so memory never shrinks.
+func (p *Packer) MemoryFootprint() int {
+	size := int(unsafe.Sizeof(*p)) +
+		(countNodes(&p.root)-1)*int(unsafe.Sizeof(node{})) +
+		cap(p.items)*int(unsafe.Sizeof(item{})) +
+		cap(p.rects)*int(unsafe.Sizeof(Rect{})) +
+		cap(p.defects)*int(unsafe.Sizeof(Rect{})) +
+		cap(p.spacing)*int(unsafe.Sizeof(spacingRule{}))
+	for _, it := range p.items {
+		size += cap(it.tags) * int(unsafe.Sizeof(""))
+		for _, t := range it.tags {
+			size += len(t)
+		}
+	}
+	return size
+}
+
+func countNodes(n *node) int {
+	count := 1
+	if n.left != nil {
+		count += countNodes(n.left)
+	}
+	if n.right != nil {
+		count += countNodes(n.right)
+	}
+	return count
+}
+
+// Stats holds usage counters of a Packer. Services can publish them, e.g. with
+//
+//	expvar.Publish("atlas", expvar.Func(func() interface{} {
+//		mu.Lock()
+//		defer mu.Unlock()
+//		return p.Stats()
+//	}))
+//
+// where mu is the lock that the goroutines modifying p hold, see DebugHandler.
+type Stats struct {
+	Inserts   int     // successful calls to Insert
+	Failures  int     // calls to Insert that returned an error
+	Removals  int     // rectangles whose region Resize or Replace freed
+	Occupancy float64 // see Packer.Occupancy
+	FreeRects int     // number of free rectangles with a non-zero area
+	// ConsecutiveFailures counts the failed inserts since the last successful
+	// one. Failures is a running total that is never reset. Together with
+	// Occupancy this tells when a nearly full bin is not worth trying anymore.
+	ConsecutiveFailures int
+}
+
+// Stats returns the current counters of p. Unlike Snapshot it is not safe to
+// call while another goroutine modifies p.
+func (p *Packer) Stats() Stats {
+	return Stats{
+		Inserts:   p.inserts,
+		Failures:  p.failures,
+		Removals:  p.removals,
+		Occupancy: p.Occupancy(),
+		FreeRects: countFree(&p.root),
+
+		ConsecutiveFailures: p.failStreak,
+	}
+}
+
+func countFree(n *node) int {
+	if n.left != nil || n.right != nil {
+		count := 0
+		if n.left != nil {
+			count += countFree(n.left)
+		}
+		if n.right != nil {
+			count += countFree(n.right)
+		}
+		return count
+	}
+	if n.Width > 0 && n.Height > 0 {
+		return 1
+	}
+	return 0
+}
+
+// WalkItems calls f for every inserted rectangle in insertion order, which
+// is stable and can serve as a frame index or draw order. i is the
+// rectangle's index in that order, tags are the tags set with Tag and must not
+// be modified. WalkItems stops early if f returns false.
+func (p *Packer) WalkItems(f func(i int, r Rect, tags []string) bool) {
+	for i, it := range p.items {
+		if !f(i, p.flip(it.Rect), it.tags) {
+			return
+		}
+	}
+}
+
+// Walk calls f for every region of the bin, used and free, depth first. A
+// region's parent is visited before its children and the children are visited
+// in the order Insert considers them for placement. Used regions hold an
+// inserted rectangle (or the whole previous bin area after Enlarge), free
+// regions are still available. Regions may have zero width or height. Walk
+// stops early if f returns false.
+func (p *Packer) Walk(f func(r Rect, used bool, depth int) bool) {
+	walk(&p.root, 0, func(r Rect, used bool, depth int) bool {
+		return f(p.flip(r), used, depth)
+	})
+}
+
+// walk visits n and all its children, depth first, left before right. It stops
+// and returns false as soon as f returns false.
+func walk(n *node, depth int, f func(r Rect, used bool, depth int) bool) bool {
+	isLeaf := n.left == nil && n.right == nil
+	if !f(n.Rect, !isLeaf, depth) {
+		return false
+	}
+	if n.left != nil && !walk(n.left, depth+1, f) {
+		return false
+	}
+	if n.right != nil && !walk(n.right, depth+1, f) {
+		return false
+	}
+	return true
+}
+
+// regionSize returns the size of the bin region that a rectangle occupies with
+// its padding and alignment, as well as the effective padding.
+func (p *Packer) regionSize(width, height, padding int) (int, int, int) {
+	if p.alignment > 1 {
+		padding = p.align(padding)
+		width, height = p.align(width), p.align(height)
+	}
+	return width + 2*padding, height + 2*padding, padding
+}
+
+// unpaddedRegionSize works like regionSize but leaves out the padding on the
+// given sides. It returns the region size and the offset of the item in it.
+func (p *Packer) unpaddedRegionSize(width, height, padding int, unpadded Edge) (regionW, regionH, padX, padY int) {
+	regionW, regionH, padding = p.regionSize(width, height, padding)
+	padX, padY = padding, padding
+	if unpadded&Left != 0 {
+		regionW -= padding
+		padX = 0
+	}
+	if unpadded&Right != 0 {
+		regionW -= padding
+	}
+	if unpadded&Top != 0 {
+		regionH -= padding
+		padY = 0
+	}
+	if unpadded&Bottom != 0 {
+		regionH -= padding
+	}
+	return
+}
+
+func (p *Packer) align(x int) int {
+	return (x + p.alignment - 1) / p.alignment * p.alignment
+}
+
+// FreeSizeHistogram counts the free regions of the bin by the largest square
+// that Insert could still place in them, taking the packer's padding into
+// account. buckets must be sorted ascending. The result has one count per
+// bucket, a region of size s counts towards bucket i if
+// buckets[i] <= s < buckets[i+1]. Regions smaller than buckets[0] are not
+// counted.
+func (p *Packer) FreeSizeHistogram(buckets []int) []int {
+	counts := make([]int, len(buckets))
+	p.Walk(func(r Rect, used bool, _ int) bool {
+		if used {
+			return true
+		}
+		size := r.Width
+		if r.Height < size {
+			size = r.Height
+		}
+		size -= 2 * p.padding
+		for i := len(buckets) - 1; i >= 0; i-- {
+			if size >= buckets[i] {
+				counts[i]++
+				break
+			}
+		}
+		return true
+	})
+	return counts
+}
+
+// InsertWaste works like Insert and also reports how much free area the
+// placement wasted: the area of the new free regions next to the rectangle
+// that are narrower or lower than minSize and thus too small for the caller's
+// typical items.
+func (p *Packer) InsertWaste(width, height, minSize int) (Rect, int, error) {
+	if err := p.writeLog("insert", width, height); err != nil {
+		return Rect{}, 0, err
+	}
+	var leaf *node
+	r, err := p.insert(width, height, p.padding, func(w, h int) (*node, bool, bool) {
+		n, right, bottom := findFree(&p.root, w, h, p.defects)
+		leaf = n
+		return n, right, bottom
+	})
+	if err != nil {
+		return Rect{}, 0, err
+	}
+	waste := 0
+	for _, c := range []*node{leaf.left, leaf.right} {
+		if c.Width < minSize || c.Height < minSize {
+			waste += c.Width * c.Height
+		}
+	}
+	return r, waste, nil
+}
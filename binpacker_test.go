@@ -1,6 +1,9 @@
 package binpacker
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestEnlarge(t *testing.T) {
 	p := New(5, 5)
@@ -10,3 +13,247 @@ func TestEnlarge(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestMemoryFootprintGrowsWithInserts(t *testing.T) {
+	p := New(100, 100)
+	empty := p.MemoryFootprint()
+	if empty <= 0 {
+		t.Fatalf("empty packer uses %d bytes", empty)
+	}
+	p.Insert(10, 10)
+	if p.MemoryFootprint() <= empty {
+		t.Errorf("footprint did not grow after Insert: %d", p.MemoryFootprint())
+	}
+	r, _ := p.At(0, 0)
+	before := p.MemoryFootprint()
+	p.Tag(r, "a long tag name")
+	if p.MemoryFootprint() <= before {
+		t.Errorf("footprint did not grow after Tag: %d", p.MemoryFootprint())
+	}
+	// Enlarge keeps the rectangles and tags
+	p.Enlarge(200, 200)
+	q := New(100, 100)
+	q.Enlarge(200, 200)
+	if p.MemoryFootprint() <= q.MemoryFootprint() {
+		t.Errorf("footprint %d is that of an empty packer after Enlarge", p.MemoryFootprint())
+	}
+}
+
+func TestEnlargeKeepsOccupancy(t *testing.T) {
+	p := New(5, 5)
+	p.Enlarge(10, 10)
+	if o := p.Occupancy(); o != 0.25 {
+		t.Errorf("want old area to count as occupied (0.25) but have %v", o)
+	}
+	var used []Rect
+	p.Walk(func(r Rect, isUsed bool, depth int) bool {
+		if isUsed {
+			used = append(used, r)
+		}
+		return true
+	})
+	if !reflect.DeepEqual(used, []Rect{{0, 0, 5, 5}}) {
+		t.Errorf("want only the old bin to be used but have %v", used)
+	}
+}
+
+func TestStatsCountInsertsAndFailures(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(5, 10)
+	p.Insert(20, 20)
+	s := p.Stats()
+	if s.Inserts != 1 || s.Failures != 1 || s.FreeRects != 1 || s.Occupancy != 0.5 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+func TestStatsCountConsecutiveFailures(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(20, 20)
+	p.Insert(20, 20)
+	if s := p.Stats(); s.Failures != 2 || s.ConsecutiveFailures != 2 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+	p.Insert(5, 5)
+	p.Insert(20, 20)
+	if s := p.Stats(); s.Failures != 3 || s.ConsecutiveFailures != 1 {
+		t.Errorf("success did not reset the streak: %+v", s)
+	}
+}
+
+func TestStatsCountRemovals(t *testing.T) {
+	p := New(10, 10)
+	r, _ := p.Insert(5, 5)
+	p.Tag(r, "a")
+	p.Replace("a", 20, 20)
+	p.Replace("a", 4, 4)
+	s := p.Stats()
+	if s.Inserts != 1 || s.Failures != 1 || s.Removals != 1 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+func TestWalkVisitsUsedAndFreeRegions(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(4, 10)
+	var used, free []Rect
+	maxDepth := 0
+	p.Walk(func(r Rect, isUsed bool, depth int) bool {
+		if isUsed {
+			used = append(used, r)
+		} else {
+			free = append(free, r)
+		}
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		return true
+	})
+	if len(used) != 1 || used[0] != (Rect{0, 0, 4, 10}) {
+		t.Errorf("unexpected used regions %v", used)
+	}
+	if len(free) != 2 || free[1] != (Rect{4, 0, 6, 10}) {
+		t.Errorf("unexpected free regions %v", free)
+	}
+	if maxDepth != 1 {
+		t.Errorf("want depth 1 but have %d", maxDepth)
+	}
+}
+
+func TestWalkStopsEarly(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(4, 10)
+	count := 0
+	p.Walk(func(Rect, bool, int) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("want 1 visit but have %d", count)
+	}
+}
+
+func TestShrinkToRepacksItems(t *testing.T) {
+	p := New(100, 100)
+	a, _ := p.Insert(10, 20)
+	b, _ := p.Insert(30, 10)
+	p.Enlarge(200, 200)
+	c, _ := p.Insert(20, 20)
+
+	mapping, err := p.ShrinkTo(50, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mapping) != 3 {
+		t.Fatalf("want 3 mapped rects but have %v", mapping)
+	}
+	for _, r := range []Rect{a, b, c} {
+		m := mapping[r]
+		if m.Width != r.Width || m.Height != r.Height ||
+			m.X < 0 || m.Y < 0 || m.X+m.Width > 50 || m.Y+m.Height > 40 {
+			t.Errorf("%v was moved to %v", r, m)
+		}
+	}
+	if o := p.Occupancy(); o != 0.45 {
+		t.Errorf("unexpected occupancy %v", o)
+	}
+}
+
+func TestShrinkToFailsWithoutChanges(t *testing.T) {
+	p := New(100, 100)
+	p.Insert(50, 50)
+	if _, err := p.ShrinkTo(40, 40); err != ErrNoMoreSpace {
+		t.Fatalf("want ErrNoMoreSpace but have %v", err)
+	}
+	if p.binWidth != 100 || p.binHeight != 100 || p.Occupancy() != 0.25 {
+		t.Error("packer was modified")
+	}
+}
+
+func TestPaddingCanBeOverriddenPerInsert(t *testing.T) {
+	p := New(100, 100)
+	p.SetPadding(2)
+	a, _ := p.Insert(10, 10)
+	if a != (Rect{2, 2, 10, 10}) {
+		t.Errorf("padded rect is at %v", a)
+	}
+	b, _ := p.InsertPadded(10, 10, 0)
+	if b != (Rect{0, 14, 10, 10}) {
+		t.Errorf("unpadded rect is at %v", b)
+	}
+	if o := p.Occupancy(); o != (14*14+10*10)/10000.0 {
+		t.Errorf("padding should count as used area but occupancy is %v", o)
+	}
+}
+
+func TestFreeSizeHistogram(t *testing.T) {
+	p := New(100, 100)
+	p.Insert(10, 90)
+	p.Insert(20, 20)
+	// free regions are now 10x10, 70x20 and 90x80
+	h := p.FreeSizeHistogram([]int{1, 16, 64, 81})
+	if len(h) != 4 || h[0] != 1 || h[1] != 1 || h[2] != 1 || h[3] != 0 {
+		t.Errorf("unexpected histogram %v", h)
+	}
+}
+
+func TestAlignmentKeepsRectsInSeparateBlocks(t *testing.T) {
+	p := New(64, 64)
+	p.SetAlignment(BlockSize)
+	p.SetPadding(1)
+	for i := 0; i < 10; i++ {
+		r, err := p.Insert(5+i, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.X%BlockSize != 0 || r.Y%BlockSize != 0 || r.Width != 5+i || r.Height != 3 {
+			t.Errorf("rect %v is not aligned", r)
+		}
+	}
+	p.Walk(func(r Rect, used bool, _ int) bool {
+		if used && (r.Width%BlockSize != 0 || r.Height%BlockSize != 0) {
+			t.Errorf("region %v does not cover whole blocks", r)
+		}
+		return true
+	})
+}
+
+func TestInsertWaste(t *testing.T) {
+	p := New(100, 100)
+	_, waste, err := p.InsertWaste(97, 50, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the 3x50 strip right of the rect is too narrow for 8x8 items
+	if waste != 3*50 {
+		t.Errorf("want waste 150 but have %d", waste)
+	}
+	if _, waste, _ = p.InsertWaste(50, 50, 8); waste != 0 {
+		t.Errorf("want no waste but have %d", waste)
+	}
+}
+
+func TestWalkItemsInInsertionOrder(t *testing.T) {
+	p := New(10, 10)
+	var want []Rect
+	for _, size := range []int{2, 5, 1, 3} {
+		r, _ := p.Insert(size, size)
+		want = append(want, r)
+	}
+	p.Tag(want[2], "third")
+
+	var have []Rect
+	p.WalkItems(func(i int, r Rect, tags []string) bool {
+		if i != len(have) {
+			t.Errorf("index %d for rect %d", i, len(have))
+		}
+		if (len(tags) == 1) != (i == 2) {
+			t.Errorf("rect %d has tags %v", i, tags)
+		}
+		have = append(have, r)
+		return i < 2
+	})
+	if !reflect.DeepEqual(have, want[:3]) {
+		t.Errorf("want %v but have %v", want[:3], have)
+	}
+}
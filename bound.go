@@ -0,0 +1,91 @@
+package binpacker
+
+// BinsLowerBound returns a lower bound for the number of bins of the given size
+// needed to pack all items, without rotation. Comparing the number of bins
+// actually used against it tells how close to optimal a packing is. The bound
+// is the maximum of the continuous (area) bound, the number of items that are
+// too big to share a bin with each other, and the bounds obtained from the
+// dual-feasible functions of Fekete and Schepers applied to widths and
+// heights. All items must fit into an empty bin.
+func BinsLowerBound(binWidth, binHeight int, items []Size) int {
+	if len(items) == 0 {
+		return 0
+	}
+	binArea := binWidth * binHeight
+
+	big := 0
+	for _, it := range items {
+		if 2*it.Width > binWidth && 2*it.Height > binHeight {
+			big++
+		}
+	}
+
+	best := big
+	for _, kw := range dffParameters(binWidth, items, func(s Size) int { return s.Width }) {
+		for _, kh := range dffParameters(binHeight, items, func(s Size) int { return s.Height }) {
+			area := 0
+			for _, it := range items {
+				area += dff(it.Width, binWidth, kw) * dff(it.Height, binHeight, kh)
+			}
+			best = max(best, ceilDiv(area, binArea))
+		}
+	}
+	return best
+}
+
+// StripLowerBound returns a lower bound for the height of a strip of the given
+// width needed to pack all items without rotation. It is the maximum of the
+// continuous (area) bound, the tallest item and the bounds obtained from the
+// dual-feasible functions of Fekete and Schepers applied to the widths.
+func StripLowerBound(stripWidth int, items []Size) int {
+	best := 0
+	for _, it := range items {
+		best = max(best, it.Height)
+	}
+	for _, k := range dffParameters(stripWidth, items, func(s Size) int { return s.Width }) {
+		area := 0
+		for _, it := range items {
+			area += dff(it.Width, stripWidth, k) * it.Height
+		}
+		best = max(best, ceilDiv(area, stripWidth))
+	}
+	return best
+}
+
+// dff is the dual-feasible function u(k) of Fekete and Schepers, scaled to
+// integers: sizes larger than capacity-k count as the whole capacity, sizes
+// smaller than k count as nothing. k == 0 is the identity, which yields the
+// continuous bound.
+func dff(size, capacity, k int) int {
+	switch {
+	case size > capacity-k:
+		return capacity
+	case size < k:
+		return 0
+	default:
+		return size
+	}
+}
+
+// dffParameters returns the values of k worth trying for dff: 0, all item
+// sizes up to half the capacity and the smallest values of k at which larger
+// items count as the whole capacity.
+func dffParameters(capacity int, items []Size, size func(Size) int) []int {
+	params := []int{0}
+	seen := map[int]bool{0: true}
+	for _, it := range items {
+		k := size(it)
+		if 2*k > capacity {
+			k = capacity - k + 1
+		}
+		if k > 0 && 2*k <= capacity && !seen[k] {
+			seen[k] = true
+			params = append(params, k)
+		}
+	}
+	return params
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,33 @@
+package binpacker
+
+import "testing"
+
+func TestBinsLowerBound(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []Size
+		want  int
+	}{
+		{"empty", nil, 0},
+		{"area", []Size{{50, 50}, {50, 50}, {50, 50}, {50, 50}, {10, 10}}, 2},
+		{"big items", []Size{{60, 60}, {60, 60}, {60, 60}}, 3},
+		// The 70 wide items cannot share a row with the 40 wide ones, which
+		// the dual-feasible function detects but the area bound does not.
+		{"dff", []Size{{70, 100}, {40, 100}, {40, 100}}, 2},
+	}
+	for _, tt := range tests {
+		if got := BinsLowerBound(100, 100, tt.items); got != tt.want {
+			t.Errorf("%s: want %d but have %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestStripLowerBound(t *testing.T) {
+	if h := StripLowerBound(100, []Size{{60, 10}, {60, 10}, {10, 30}}); h != 30 {
+		t.Errorf("want 30 but have %d", h)
+	}
+	// two items wider than half the strip cannot be side by side
+	if h := StripLowerBound(100, []Size{{60, 10}, {60, 10}}); h != 20 {
+		t.Errorf("want 20 but have %d", h)
+	}
+}
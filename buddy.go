@@ -0,0 +1,233 @@
+package binpacker
+
+import (
+	"errors"
+	"time"
+)
+
+// BuddyPacker is a two-dimensional buddy allocator. The bin is a square with a
+// power of two side length which is recursively split into four equal square
+// cells. Every rectangle occupies a whole cell, its size is rounded up to the
+// next power of two. This wastes space but makes Insert and Remove run in
+// O(log n) and freed cells merge with their neighbors immediately, which makes
+// the memory behavior very predictable.
+type BuddyPacker struct {
+	size, minCell int
+	root          buddyNode
+	holds         map[Rect]time.Time // tentative cells and their expiry
+}
+
+type buddyNode struct {
+	used     bool
+	children *[4]buddyNode
+	// maxFree is the side length of the largest free cell in this sub-tree.
+	maxFree int
+}
+
+// NewBuddy creates a BuddyPacker for a square bin of the given side length.
+// Cells are never split below minCell. Both sizes must be powers of two.
+func NewBuddy(size, minCell int) (*BuddyPacker, error) {
+	if !isPowerOfTwo(size) || !isPowerOfTwo(minCell) || minCell > size {
+		return nil, errors.New("new buddy: sizes must be powers of two and minCell <= size")
+	}
+	return &BuddyPacker{
+		size:    size,
+		minCell: minCell,
+		root:    buddyNode{maxFree: size},
+	}, nil
+}
+
+// Insert allocates a cell for a rectangle of the given size and returns the
+// rectangle's position in the top-left corner of that cell.
+func (b *BuddyPacker) Insert(width, height int) (Rect, error) {
+	b.releaseExpired()
+	cell := b.cellSize(width, height)
+	x, y, ok := b.root.insert(0, 0, b.size, cell)
+	if !ok {
+		return Rect{}, ErrNoMoreSpace
+	}
+	return Rect{X: x, Y: y, Width: width, Height: height}, nil
+}
+
+// Hold works like Insert but only reserves the cell tentatively. Unless it is
+// confirmed with Confirm within the given time, the cell is freed again by the
+// next Insert or Hold. A hold can also be given up early with Remove.
+func (b *BuddyPacker) Hold(width, height int, ttl time.Duration) (Rect, error) {
+	r, err := b.Insert(width, height)
+	if err != nil {
+		return r, err
+	}
+	if b.holds == nil {
+		b.holds = make(map[Rect]time.Time)
+	}
+	b.holds[r] = time.Now().Add(ttl)
+	return r, nil
+}
+
+// Confirm turns a hold into a regular insert. It fails if r is not held or its
+// hold has expired, in which case the cell is freed.
+func (b *BuddyPacker) Confirm(r Rect) error {
+	expiry, ok := b.holds[r]
+	if !ok {
+		return errors.New("confirm: rect is not held")
+	}
+	delete(b.holds, r)
+	if time.Now().After(expiry) {
+		b.remove(r, true)
+		return errors.New("confirm: hold has expired")
+	}
+	return nil
+}
+
+func (b *BuddyPacker) releaseExpired() {
+	now := time.Now()
+	for r, expiry := range b.holds {
+		if now.After(expiry) {
+			delete(b.holds, r)
+			b.remove(r, true)
+		}
+	}
+}
+
+// Remove frees the cell of a rectangle returned by Insert or Hold.
+func (b *BuddyPacker) Remove(r Rect) error {
+	delete(b.holds, r)
+	if !b.remove(r, true) {
+		return errors.New("remove: rect was not inserted")
+	}
+	return nil
+}
+
+// RemoveRects frees many cells at once and merges the freed cells in a single
+// pass at the end instead of after every removal. It returns the rectangles
+// that were not inserted and thus could not be removed.
+func (b *BuddyPacker) RemoveRects(rects []Rect) (failed []Rect) {
+	for _, r := range rects {
+		delete(b.holds, r)
+		if !b.remove(r, false) {
+			failed = append(failed, r)
+		}
+	}
+	b.root.coalesce(b.size)
+	return failed
+}
+
+func (b *BuddyPacker) remove(r Rect, merge bool) bool {
+	cell := b.cellSize(r.Width, r.Height)
+	return r.X%cell == 0 && r.Y%cell == 0 &&
+		b.root.remove(0, 0, b.size, r.X, r.Y, cell, merge)
+}
+
+// Occupancy returns the fraction of the bin covered by used cells.
+func (b *BuddyPacker) Occupancy() float64 {
+	return float64(b.root.usedArea(b.size)) / float64(b.size*b.size)
+}
+
+func (b *BuddyPacker) cellSize(width, height int) int {
+	return nextPowerOfTwo(max(b.minCell, max(width, height)))
+}
+
+func (n *buddyNode) insert(x, y, size, cell int) (int, int, bool) {
+	if n.maxFree < cell {
+		return 0, 0, false
+	}
+	if size == cell {
+		n.used = true
+		n.maxFree = 0
+		return x, y, true
+	}
+	half := size / 2
+	if n.children == nil {
+		n.children = &[4]buddyNode{{maxFree: half}, {maxFree: half}, {maxFree: half}, {maxFree: half}}
+	}
+	for i := range n.children {
+		c := &n.children[i]
+		if c.maxFree >= cell {
+			cx, cy, _ := c.insert(x+i%2*half, y+i/2*half, half, cell)
+			n.updateMaxFree()
+			return cx, cy, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (n *buddyNode) remove(x, y, size, rx, ry, cell int, merge bool) bool {
+	if size == cell {
+		if !n.used {
+			return false
+		}
+		n.used = false
+		n.maxFree = size
+		return true
+	}
+	if n.children == nil || size < cell {
+		return false
+	}
+	half := size / 2
+	i := 0
+	if rx >= x+half {
+		i++
+	}
+	if ry >= y+half {
+		i += 2
+	}
+	if !n.children[i].remove(x+i%2*half, y+i/2*half, half, rx, ry, cell, merge) {
+		return false
+	}
+	n.updateMaxFree()
+	if merge && n.maxFree == half && n.allChildrenFree(half) {
+		n.children = nil
+		n.maxFree = size
+	}
+	return true
+}
+
+// coalesce merges all free sibling cells in the sub-tree, bottom up.
+func (n *buddyNode) coalesce(size int) {
+	if n.children == nil {
+		return
+	}
+	half := size / 2
+	for i := range n.children {
+		n.children[i].coalesce(half)
+	}
+	n.updateMaxFree()
+	if n.maxFree == half && n.allChildrenFree(half) {
+		n.children = nil
+		n.maxFree = size
+	}
+}
+
+func (n *buddyNode) updateMaxFree() {
+	n.maxFree = 0
+	for _, c := range n.children {
+		n.maxFree = max(n.maxFree, c.maxFree)
+	}
+}
+
+func (n *buddyNode) allChildrenFree(half int) bool {
+	for _, c := range n.children {
+		if c.maxFree != half {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *buddyNode) usedArea(size int) int {
+	if n.used {
+		return size * size
+	}
+	if n.children == nil {
+		return 0
+	}
+	area := 0
+	for i := range n.children {
+		area += n.children[i].usedArea(size / 2)
+	}
+	return area
+}
+
+func isPowerOfTwo(x int) bool {
+	return x > 0 && x&(x-1) == 0
+}
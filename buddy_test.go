@@ -0,0 +1,94 @@
+package binpacker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuddyInsertAndRemove(t *testing.T) {
+	b, err := NewBuddy(64, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	big, err := b.Insert(20, 30) // rounded up to 32x32
+	if err != nil {
+		t.Fatal(err)
+	}
+	if big != (Rect{0, 0, 20, 30}) {
+		t.Errorf("unexpected placement %v", big)
+	}
+	small, _ := b.Insert(3, 3) // rounded up to 8x8
+	if small != (Rect{32, 0, 3, 3}) {
+		t.Errorf("unexpected placement %v", small)
+	}
+	if o := b.Occupancy(); o != (32*32+8*8)/(64*64.0) {
+		t.Errorf("unexpected occupancy %v", o)
+	}
+
+	if err := b.Remove(small); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Remove(small); err == nil {
+		t.Error("removing twice should fail")
+	}
+	if err := b.Remove(big); err != nil {
+		t.Fatal(err)
+	}
+	// after merging all cells back together, the whole bin is free again
+	if _, err := b.Insert(64, 64); err != nil {
+		t.Errorf("bin was not coalesced: %v", err)
+	}
+	if _, err := b.Insert(1, 1); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace but have %v", err)
+	}
+}
+
+func TestNewBuddyNeedsPowersOfTwo(t *testing.T) {
+	if _, err := NewBuddy(100, 4); err == nil {
+		t.Error("want error for non-power of two size")
+	}
+}
+
+func TestBuddyRemoveRects(t *testing.T) {
+	b, _ := NewBuddy(64, 8)
+	var rects []Rect
+	for i := 0; i < 64; i++ {
+		r, err := b.Insert(8, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rects = append(rects, r)
+	}
+	missing := Rect{X: 1, Y: 1, Width: 8, Height: 8}
+	failed := b.RemoveRects(append(rects, missing))
+	if len(failed) != 1 || failed[0] != missing {
+		t.Errorf("unexpected failures %v", failed)
+	}
+	if _, err := b.Insert(64, 64); err != nil {
+		t.Errorf("bin was not coalesced: %v", err)
+	}
+}
+
+func TestBuddyHold(t *testing.T) {
+	b, _ := NewBuddy(16, 8)
+	kept, _ := b.Hold(8, 8, time.Hour)
+	expired, _ := b.Hold(8, 8, -time.Second)
+	if err := b.Confirm(kept); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Confirm(kept); err == nil {
+		t.Error("confirming twice should fail")
+	}
+	if err := b.Confirm(expired); err == nil {
+		t.Error("expired hold was confirmed")
+	}
+	if b.Occupancy() != 0.25 {
+		t.Errorf("occupancy is %v, expired cell was not freed", b.Occupancy())
+	}
+
+	b.Hold(8, 8, -time.Second)
+	b.Insert(8, 8) // frees the expired hold first
+	if b.Occupancy() != 0.5 {
+		t.Errorf("occupancy is %v", b.Occupancy())
+	}
+}
@@ -0,0 +1,106 @@
+package binpacker
+
+// Edge is a set of bin borders, e.g. Left|Top.
+type Edge int
+
+const (
+	Left Edge = 1 << iota
+	Top
+	Right
+	Bottom
+)
+
+// InsertAtEdge works like Insert but only places the rectangle so that it
+// touches all the given edges of the bin. There is no padding between the
+// rectangle and these edges, on its other sides the padding applies as usual.
+func (p *Packer) InsertAtEdge(width, height int, edges Edge) (Rect, error) {
+	if err := p.writeLog("edge", width, height, int(edges)); err != nil {
+		return Rect{}, err
+	}
+	bin := p.trimmed(p.binWidth, p.binHeight)
+	binX, binY := bin.X, bin.Y
+	binRight, binBottom := binX+bin.Width, binY+bin.Height
+	return p.insertUnpadded(width, height, p.padding, edges, func(w, h int) (*node, bool, bool) {
+		var found *node
+		right, bottom := edges&Right != 0, edges&Bottom != 0
+		leaves(&p.root, func(n *node) bool {
+			if w > n.Width || h > n.Height {
+				return true
+			}
+			x, y := n.X, n.Y
+			if right {
+				x = n.X + n.Width - w
+			}
+			if bottom {
+				y = n.Y + n.Height - h
+			}
+			if edges&Left != 0 && x != binX ||
+				edges&Top != 0 && y != binY ||
+				right && x+w != binRight ||
+				bottom && y+h != binBottom ||
+				hitsDefect(Rect{X: x, Y: y, Width: w, Height: h}, p.defects) {
+				return true
+			}
+			found = n
+			return false
+		})
+		return found, right, bottom
+	})
+}
+
+// InsertNearCorner works like Insert but places the rectangle as close as
+// possible to the given corner of the bin, e.g. Top|Left. The first rectangle
+// inserted this way lands exactly in the corner if it is still free, later
+// ones fill the bin outward from it.
+func (p *Packer) InsertNearCorner(width, height int, corner Edge) (Rect, error) {
+	if err := p.writeLog("corner", width, height, int(corner)); err != nil {
+		return Rect{}, err
+	}
+	right, bottom := corner&Right != 0, corner&Bottom != 0
+	bin := p.trimmed(p.binWidth, p.binHeight)
+	cornerX, cornerY := bin.X, bin.Y
+	if right {
+		cornerX += bin.Width
+	}
+	if bottom {
+		cornerY += bin.Height
+	}
+	return p.insert(width, height, p.padding, func(w, h int) (*node, bool, bool) {
+		var best *node
+		bestDist := 0
+		leaves(&p.root, func(n *node) bool {
+			if w > n.Width || h > n.Height {
+				return true
+			}
+			x, y := n.X, n.Y
+			if right {
+				x = n.X + n.Width - w
+			}
+			if bottom {
+				y = n.Y + n.Height - h
+			}
+			if hitsDefect(Rect{X: x, Y: y, Width: w, Height: h}, p.defects) {
+				return true
+			}
+			if right {
+				x += w
+			}
+			if bottom {
+				y += h
+			}
+			dist := abs(x-cornerX) + abs(y-cornerY)
+			if best == nil || dist < bestDist {
+				best, bestDist = n, dist
+			}
+			return true
+		})
+		return best, right, bottom
+	})
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
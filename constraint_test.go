@@ -0,0 +1,89 @@
+package binpacker
+
+import "testing"
+
+func TestInsertAtEdge(t *testing.T) {
+	p := New(100, 100)
+	p.Insert(10, 10)
+
+	r, err := p.InsertAtEdge(20, 10, Right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.X+r.Width != 100 {
+		t.Errorf("%v does not touch the right edge", r)
+	}
+	r, err = p.InsertAtEdge(20, 10, Bottom|Right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != (Rect{80, 90, 20, 10}) {
+		t.Errorf("%v is not in the bottom-right corner", r)
+	}
+	if _, err := p.InsertAtEdge(10, 10, Top|Left); err != ErrNoMoreSpace {
+		t.Errorf("top-left corner is taken but have %v", err)
+	}
+	if items := p.ItemsIn(Rect{0, 0, 100, 100}); Collisions(items) != nil {
+		t.Errorf("items overlap: %v", items)
+	}
+}
+
+func TestInsertAtEdgeWithYUp(t *testing.T) {
+	p := New(100, 100)
+	p.SetYUp(true)
+	r, _ := p.InsertAtEdge(10, 10, Top)
+	if r.Y+r.Height != 100 {
+		t.Errorf("%v does not touch the top edge", r)
+	}
+}
+
+func TestInsertNearCorner(t *testing.T) {
+	p := New(100, 100)
+	p.Insert(50, 50)
+
+	r, _ := p.InsertNearCorner(10, 10, Bottom|Right)
+	if r != (Rect{90, 90, 10, 10}) {
+		t.Errorf("%v is not in the bottom-right corner", r)
+	}
+	r, _ = p.InsertNearCorner(5, 5, Bottom|Right)
+	if r.X+r.Width != 90 && r.Y+r.Height != 90 {
+		t.Errorf("%v is not next to the first corner item", r)
+	}
+	r, _ = p.InsertNearCorner(5, 5, Top|Left)
+	if r != (Rect{0, 50, 5, 5}) && r != (Rect{50, 0, 5, 5}) {
+		t.Errorf("%v is not next to the taken top-left corner", r)
+	}
+}
+
+func TestInsertAtEdgeAfterTrim(t *testing.T) {
+	p := New(100, 100)
+	p.Trim(5, 5, 5, 5)
+	r, err := p.InsertAtEdge(10, 10, Bottom|Right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != (Rect{85, 85, 10, 10}) {
+		t.Errorf("%v is not in the trimmed bottom-right corner", r)
+	}
+	r, _ = p.InsertNearCorner(10, 10, Top|Left)
+	if r != (Rect{5, 5, 10, 10}) {
+		t.Errorf("%v is not in the trimmed top-left corner", r)
+	}
+}
+
+func TestInsertAtEdgeSkipsPaddingAtTheEdges(t *testing.T) {
+	p := New(100, 100)
+	p.SetPadding(2)
+	r, _ := p.InsertAtEdge(10, 10, Left|Top)
+	if r != (Rect{0, 0, 10, 10}) {
+		t.Errorf("%v does not touch the top-left corner", r)
+	}
+	// the padding still applies on the right and bottom
+	if next, _ := p.Insert(5, 5); next.X < 12 && next.Y < 12 {
+		t.Errorf("%v is inside the padding of %v", next, r)
+	}
+	r, _ = p.InsertAtEdge(10, 10, Right)
+	if r.X+r.Width != 100 {
+		t.Errorf("%v does not touch the right edge", r)
+	}
+}
@@ -0,0 +1,29 @@
+package binpacker
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteCSV writes all inserted rectangles to w as CSV with a header line. The
+// columns are the insertion index, x, y, width, height and the item's tags,
+// separated by spaces.
+func (p *Packer) WriteCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+	c.Write([]string{"index", "x", "y", "width", "height", "tags"})
+	for i, it := range p.items {
+		r := p.flip(it.Rect)
+		c.Write([]string{
+			strconv.Itoa(i),
+			strconv.Itoa(r.X),
+			strconv.Itoa(r.Y),
+			strconv.Itoa(r.Width),
+			strconv.Itoa(r.Height),
+			strings.Join(it.tags, " "),
+		})
+	}
+	c.Flush()
+	return c.Error()
+}
@@ -0,0 +1,24 @@
+package binpacker
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	p := New(100, 100)
+	a, _ := p.Insert(10, 20)
+	p.Insert(30, 5)
+	p.Tag(a, "ui", "font")
+
+	var buf bytes.Buffer
+	if err := p.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "index,x,y,width,height,tags\n" +
+		"0,0,0,10,20,ui font\n" +
+		"1,10,0,30,5,\n"
+	if buf.String() != want {
+		t.Errorf("want\n%s\nbut have\n%s", want, buf.String())
+	}
+}
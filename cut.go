@@ -0,0 +1,168 @@
+package binpacker
+
+import (
+	"fmt"
+	"io"
+)
+
+// Cut is a straight guillotine cut that starts at (X, Y) and runs Length
+// pixels (or units of material) along the X axis if it is Horizontal, along
+// the Y axis otherwise.
+type Cut struct {
+	X, Y       int
+	Length     int
+	Horizontal bool
+}
+
+// CutList returns the guillotine cuts that separate all used regions of the
+// bin, in the order they have to be made, and the free rectangles (offcuts)
+// that remain. Every cut goes all the way through the piece it divides. When
+// cutting material, the padding can be used as an allowance for the kerf. If
+// the bin is trimmed, the cuts start with the trim cuts, see Trim.
+func (p *Packer) CutList() (cuts []Cut, offcuts []Rect) {
+	var visit func(n *node)
+	visit = func(n *node) {
+		if n.left == nil && n.right == nil {
+			if n.Width > 0 && n.Height > 0 {
+				offcuts = append(offcuts, p.flip(n.Rect))
+			}
+			return
+		}
+
+		// The children split the free part of the region into two rects, one
+		// of which spans the whole region. The cut separating that child has
+		// to be made first, then the cut between the used part and the other
+		// child. Used parts can lie in any corner of the region.
+		region, used := extent(n), n.Rect
+		var full, other *Rect
+		for _, c := range []*node{n.left, n.right} {
+			if c == nil {
+				continue
+			}
+			e := extent(c)
+			if e.Width <= 0 || e.Height <= 0 {
+				continue // there is nothing to cut off
+			}
+			spans := e.X == region.X && e.Width == region.Width ||
+				e.Y == region.Y && e.Height == region.Height
+			if full == nil && spans {
+				full = &e
+			} else {
+				other = &e
+			}
+		}
+		if full != nil {
+			cuts = append(cuts, p.flipCut(cutOff(region, *full)))
+		}
+		// A region freed by Resize leaves an empty used part behind which
+		// needs no cut of its own.
+		if other != nil && used.Width > 0 && used.Height > 0 {
+			cuts = append(cuts, p.flipCut(cutBetween(used, *other)))
+		}
+
+		if n.left != nil {
+			visit(n.left)
+		}
+		if n.right != nil {
+			visit(n.right)
+		}
+	}
+	for _, c := range p.trimCuts() {
+		cuts = append(cuts, p.flipCut(c))
+	}
+	visit(&p.root)
+	return
+}
+
+// WriteCutList writes the result of CutList to w in a human-readable form.
+func (p *Packer) WriteCutList(w io.Writer) error {
+	cuts, offcuts := p.CutList()
+	if _, err := fmt.Fprintf(w, "sheet %dx%d, %d cuts\n", p.binWidth, p.binHeight, len(cuts)); err != nil {
+		return err
+	}
+	for i, c := range cuts {
+		dir := "vertical  "
+		if c.Horizontal {
+			dir = "horizontal"
+		}
+		if _, err := fmt.Fprintf(w, "%4d. %s cut at %d,%d length %d\n", i+1, dir, c.X, c.Y, c.Length); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%d offcuts\n", len(offcuts)); err != nil {
+		return err
+	}
+	for _, r := range offcuts {
+		if _, err := fmt.Fprintf(w, "      %dx%d at %d,%d\n", r.Width, r.Height, r.X, r.Y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cutOff returns the cut that separates the strip from the rest of region. The
+// strip lies inside region and spans its whole width or height.
+func cutOff(region, strip Rect) Cut {
+	if strip.X == region.X && strip.Width == region.Width {
+		y := strip.Y
+		if y == region.Y {
+			y += strip.Height
+		}
+		return Cut{X: region.X, Y: y, Length: region.Width, Horizontal: true}
+	}
+	x := strip.X
+	if x == region.X {
+		x += strip.Width
+	}
+	return Cut{X: x, Y: region.Y, Length: region.Height}
+}
+
+// cutBetween returns the cut that separates r from its neighbor next to,
+// above or below it. The cut runs along the whole side of r.
+func cutBetween(r, neighbor Rect) Cut {
+	if neighbor.Y < r.Y+r.Height && r.Y < neighbor.Y+neighbor.Height {
+		x := r.X
+		if neighbor.X >= r.X+r.Width {
+			x = neighbor.X
+		}
+		return Cut{X: x, Y: r.Y, Length: r.Height}
+	}
+	y := r.Y
+	if neighbor.Y >= r.Y+r.Height {
+		y = neighbor.Y
+	}
+	return Cut{X: r.X, Y: y, Length: r.Width, Horizontal: true}
+}
+
+// extent returns the region that n covered before it was split.
+func extent(n *node) Rect {
+	r := n.Rect
+	for _, c := range []*node{n.left, n.right} {
+		if c != nil {
+			r = union(r, extent(c))
+		}
+	}
+	return r
+}
+
+func union(a, b Rect) Rect {
+	x0, y0 := min(a.X, b.X), min(a.Y, b.Y)
+	x1, y1 := max(a.X+a.Width, b.X+b.Width), max(a.Y+a.Height, b.Y+b.Height)
+	return Rect{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
+
+func (p *Packer) flipCut(c Cut) Cut {
+	r := Rect{X: c.X, Y: c.Y}
+	if !c.Horizontal {
+		r.Height = c.Length
+	}
+	c.Y = p.flip(r).Y
+	return c
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
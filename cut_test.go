@@ -0,0 +1,119 @@
+package binpacker
+
+import "testing"
+
+func TestCutList(t *testing.T) {
+	p := New(100, 50)
+	p.Insert(30, 50)
+	p.Insert(40, 20)
+
+	cuts, offcuts := p.CutList()
+	checkCuts(t, cuts, []Cut{
+		{X: 30, Y: 0, Length: 50},
+		{X: 70, Y: 0, Length: 50},
+		{X: 30, Y: 20, Length: 40, Horizontal: true},
+	})
+	wantOffcuts := []Rect{{30, 20, 40, 30}, {70, 0, 30, 50}}
+	if len(offcuts) != 2 || offcuts[0] != wantOffcuts[0] || offcuts[1] != wantOffcuts[1] {
+		t.Errorf("want offcuts %v but have %v", wantOffcuts, offcuts)
+	}
+}
+
+func TestCutListCutsFullWidthFirst(t *testing.T) {
+	p := New(10, 10)
+	p.Enlarge(20, 20)
+	cuts, _ := p.CutList()
+	checkCuts(t, cuts, []Cut{
+		{X: 0, Y: 10, Length: 20, Horizontal: true},
+		{X: 10, Y: 0, Length: 10},
+	})
+}
+
+func checkCuts(t *testing.T, have, want []Cut) {
+	t.Helper()
+	if len(have) != len(want) {
+		t.Fatalf("want cuts %v but have %v", want, have)
+	}
+	for i := range have {
+		if have[i] != want[i] {
+			t.Errorf("cut %d: want %v but have %v", i, want[i], have[i])
+		}
+	}
+}
+
+func TestCutListWithCornerPlacements(t *testing.T) {
+	p := New(100, 100)
+	p.InsertNearCorner(10, 20, Bottom|Right)
+	cuts, _ := p.CutList()
+	checkCuts(t, cuts, []Cut{
+		{X: 90, Y: 0, Length: 100},
+		{X: 90, Y: 80, Length: 10, Horizontal: true},
+	})
+
+	// the defect makes Insert use the top-right corner
+	p = New(100, 100)
+	p.AddDefects(Rect{0, 0, 10, 10})
+	p.Insert(20, 20)
+	cuts, _ = p.CutList()
+	checkCuts(t, cuts, []Cut{
+		{X: 80, Y: 0, Length: 100},
+		{X: 80, Y: 20, Length: 20, Horizontal: true},
+	})
+}
+
+func TestCutListAfterResize(t *testing.T) {
+	p := New(50, 40)
+	a, _ := p.Insert(10, 10)
+	p.Tag(a, "a")
+	p.Insert(10, 10)
+	p.Resize("a", 5, 5)
+
+	cuts, offcuts := p.CutList()
+	checkCuts(t, cuts[:2], []Cut{
+		{X: 10, Y: 0, Length: 40},
+		{X: 0, Y: 10, Length: 10, Horizontal: true},
+	})
+	pieces := sawCuts(t, Rect{0, 0, 50, 40}, cuts)
+	want := append(p.ItemsIn(Rect{0, 0, 50, 40}), offcuts...)
+	if len(pieces) != len(want) {
+		t.Fatalf("cutting gave %v but want %v", pieces, want)
+	}
+	for _, r := range want {
+		found := false
+		for _, piece := range pieces {
+			found = found || piece == r
+		}
+		if !found {
+			t.Errorf("cutting did not produce %v: %v", r, pieces)
+		}
+	}
+}
+
+// sawCuts makes the cuts in order, each has to go all the way through one of
+// the pieces, and returns the resulting pieces.
+func sawCuts(t *testing.T, sheet Rect, cuts []Cut) []Rect {
+	t.Helper()
+	pieces := []Rect{sheet}
+	for _, c := range cuts {
+		cut := false
+		for i, r := range pieces {
+			var a, b Rect
+			if c.Horizontal && c.X == r.X && c.Length == r.Width && r.Y < c.Y && c.Y < r.Y+r.Height {
+				a = Rect{r.X, r.Y, r.Width, c.Y - r.Y}
+				b = Rect{r.X, c.Y, r.Width, r.Y + r.Height - c.Y}
+			} else if !c.Horizontal && c.Y == r.Y && c.Length == r.Height && r.X < c.X && c.X < r.X+r.Width {
+				a = Rect{r.X, r.Y, c.X - r.X, r.Height}
+				b = Rect{c.X, r.Y, r.X + r.Width - c.X, r.Height}
+			} else {
+				continue
+			}
+			pieces = append(append(pieces[:i], pieces[i+1:]...), a, b)
+			cut = true
+			break
+		}
+		if !cut {
+			t.Fatalf("%v does not go through any piece of %v", c, pieces)
+		}
+	}
+	return pieces
+}
@@ -0,0 +1,86 @@
+package binpacker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DebugHandler returns an http.Handler that shows the current layout of p. It
+// serves an SVG image of the used (blue) and free (white) areas, or a JSON
+// snapshot if the request URL contains the query parameter format=json.
+// If p is modified by other goroutines, pass the lock that guards it as mu,
+// otherwise mu may be nil. The SVG and JSON are deterministic, see the package
+// documentation.
+func DebugHandler(p *Packer, mu sync.Locker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mu != nil {
+			mu.Lock()
+		}
+		s := p.debugSnapshot()
+		if mu != nil {
+			mu.Unlock()
+		}
+
+		if r.URL.Query().Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(s)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/svg+xml")
+		fmt.Fprintf(w,
+			`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%d %d %d %d">`+"\n",
+			s.Width, s.Height, s.X, s.Y, s.Width, s.Height,
+		)
+		for _, rect := range s.Used {
+			writeSVGRect(w, rect, "#4080ff")
+		}
+		for _, rect := range s.Free {
+			writeSVGRect(w, rect, "#ffffff")
+		}
+		fmt.Fprintln(w, "</svg>")
+	})
+}
+
+type debugSnapshot struct {
+	X         int     `json:"x"`
+	Y         int     `json:"y"`
+	Width     int     `json:"width"`
+	Height    int     `json:"height"`
+	Occupancy float64 `json:"occupancy"`
+	Used      []Rect  `json:"used"`
+	Free      []Rect  `json:"free"`
+}
+
+func (p *Packer) debugSnapshot() debugSnapshot {
+	s := debugSnapshot{
+		X:         p.originX,
+		Y:         p.originY,
+		Width:     p.binWidth,
+		Height:    p.binHeight,
+		Occupancy: p.Occupancy(),
+		Used:      []Rect{},
+		Free:      []Rect{},
+	}
+	p.Walk(func(r Rect, used bool, depth int) bool {
+		if r.Width > 0 && r.Height > 0 {
+			if used {
+				s.Used = append(s.Used, r)
+			} else {
+				s.Free = append(s.Free, r)
+			}
+		}
+		return true
+	})
+	return s
+}
+
+func writeSVGRect(w io.Writer, r Rect, color string) {
+	fmt.Fprintf(w,
+		`<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="black"/>`+"\n",
+		r.X, r.Y, r.Width, r.Height, color,
+	)
+}
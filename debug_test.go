@@ -0,0 +1,91 @@
+package binpacker
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugHandlerServesJSONSnapshot(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(5, 10)
+
+	rec := httptest.NewRecorder()
+	DebugHandler(p, nil).ServeHTTP(rec, httptest.NewRequest("GET", "/?format=json", nil))
+
+	var s debugSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Width != 10 || s.Height != 10 || len(s.Used) != 1 || len(s.Free) != 1 {
+		t.Errorf("unexpected snapshot: %+v", s)
+	}
+}
+
+func TestDebugHandlerServesSVG(t *testing.T) {
+	rec := httptest.NewRecorder()
+	DebugHandler(New(10, 10), nil).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if !strings.HasPrefix(rec.Body.String(), "<svg") {
+		t.Errorf("want SVG but have %q", rec.Body.String())
+	}
+}
+
+func TestDebugOutputIsDeterministic(t *testing.T) {
+	render := func(query string) string {
+		p := New(64, 64)
+		for i := 1; i <= 8; i++ {
+			p.Insert(i*3, 11-i)
+		}
+		rec := httptest.NewRecorder()
+		DebugHandler(p, nil).ServeHTTP(rec, httptest.NewRequest("GET", "/"+query, nil))
+		return rec.Body.String()
+	}
+	for _, query := range []string{"", "?format=json"} {
+		first := render(query)
+		for i := 0; i < 10; i++ {
+			if render(query) != first {
+				t.Fatalf("output for %q differs between runs", query)
+			}
+		}
+	}
+}
+
+func TestExportsAreDeterministic(t *testing.T) {
+	build := func() *Packer {
+		p := New(64, 64)
+		p.SetPadding(1)
+		p.Trim(1, 2, 3, 4)
+		p.AddDefects(Rect{30, 30, 4, 4})
+		for i := 1; i <= 8; i++ {
+			r, _ := p.Insert(i*3, 11-i)
+			p.Tag(r, "sprite"+string(rune('a'+i)), "all")
+		}
+		return p
+	}
+	exports := []struct {
+		name   string
+		export func(p *Packer, w io.Writer) error
+	}{
+		{"WriteCSV", (*Packer).WriteCSV},
+		{"WriteCutList", (*Packer).WriteCutList},
+		{"Report", (*Packer).Report},
+		{"WriteGoSource", func(p *Packer, w io.Writer) error { return p.WriteGoSource(w, "atlas", "") }},
+	}
+	for _, e := range exports {
+		name, export := e.name, e.export
+		var first bytes.Buffer
+		if err := export(build(), &first); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for i := 0; i < 10; i++ {
+			var buf bytes.Buffer
+			export(build(), &buf)
+			if !bytes.Equal(buf.Bytes(), first.Bytes()) {
+				t.Fatalf("%s output differs between runs", name)
+			}
+		}
+	}
+}
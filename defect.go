@@ -0,0 +1,105 @@
+package binpacker
+
+import "image"
+
+// AddDefects marks regions of the bin as unusable, e.g. knots or damaged areas
+// of a sheet. No rectangle will be placed so that it overlaps a defect.
+// Defects only affect rectangles inserted afterwards.
+func (p *Packer) AddDefects(defects ...Rect) error {
+	for _, d := range defects {
+		if err := p.writeLog("defect", d.X, d.Y, d.Width, d.Height); err != nil {
+			return err
+		}
+		p.defects = append(p.defects, p.flip(d))
+	}
+	return nil
+}
+
+// AddDefectMask marks the defects of a low resolution mask image, see
+// DefectsFromMask. The mask covers the bin from its top-left corner, wherever
+// SetOrigin put it, and its top row is the top of the bin even with SetYUp.
+func (p *Packer) AddDefectMask(mask image.Image, cellWidth, cellHeight int) error {
+	defects := DefectsFromMask(mask, cellWidth, cellHeight)
+	for i, d := range defects {
+		d.X += p.originX
+		d.Y += p.originY
+		defects[i] = p.flip(d)
+	}
+	return p.AddDefects(defects...)
+}
+
+// DefectsFromMask converts a low resolution mask image to defect regions.
+// Every pixel of the mask that is not fully transparent marks a defect of
+// cellWidth x cellHeight in the bin. Neighboring pixels in a row are combined
+// into one region. The regions are relative to (0, 0) with Y pointing down,
+// not to the packer's coordinates, so use AddDefectMask on a packer that was
+// moved with SetOrigin or uses SetYUp.
+func DefectsFromMask(mask image.Image, cellWidth, cellHeight int) []Rect {
+	var defects []Rect
+	b := mask.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		start := -1
+		for x := b.Min.X; x <= b.Max.X; x++ {
+			opaque := false
+			if x < b.Max.X {
+				_, _, _, a := mask.At(x, y).RGBA()
+				opaque = a != 0
+			}
+			if opaque && start == -1 {
+				start = x
+			}
+			if !opaque && start != -1 {
+				defects = append(defects, Rect{
+					X:      (start - b.Min.X) * cellWidth,
+					Y:      (y - b.Min.Y) * cellHeight,
+					Width:  (x - start) * cellWidth,
+					Height: cellHeight,
+				})
+				start = -1
+			}
+		}
+	}
+	return defects
+}
+
+// findFree returns the first leaf in which a rectangle of the given size fits
+// without overlapping any of the defects, and the leaf's corner to place it
+// in. It returns nil if there is no such leaf.
+func findFree(root *node, width, height int, defects []Rect) (leaf *node, right, bottom bool) {
+	return findFreeWhere(root, width, height, func(r Rect) bool {
+		return !hitsDefect(r, defects)
+	})
+}
+
+// findFreeWhere works like findFree but accepts a region r only if ok(r).
+func findFreeWhere(root *node, width, height int, ok func(r Rect) bool) (leaf *node, right, bottom bool) {
+	leaves(root, func(n *node) bool {
+		if width > n.Width || height > n.Height {
+			return true
+		}
+		for _, corner := range [4][2]bool{{false, false}, {true, false}, {false, true}, {true, true}} {
+			r := Rect{X: n.X, Y: n.Y, Width: width, Height: height}
+			if corner[0] {
+				r.X += n.Width - width
+			}
+			if corner[1] {
+				r.Y += n.Height - height
+			}
+			if ok(r) {
+				leaf, right, bottom = n, corner[0], corner[1]
+				return false
+			}
+		}
+		return true
+	})
+	return
+}
+
+func hitsDefect(r Rect, defects []Rect) bool {
+	for _, d := range defects {
+		if r.Intersects(d) {
+			return true
+		}
+	}
+	return false
+}
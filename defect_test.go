@@ -0,0 +1,60 @@
+package binpacker
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestInsertAvoidsDefects(t *testing.T) {
+	p := New(100, 100)
+	defect := Rect{X: 5, Y: 5, Width: 2, Height: 2}
+	p.AddDefects(defect)
+
+	for i := 0; i < 20; i++ {
+		r, err := p.Insert(10, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Intersects(defect) {
+			t.Fatalf("%v overlaps the defect", r)
+		}
+	}
+	if items := p.ItemsIn(defect); len(items) != 0 {
+		t.Errorf("defect area is used by %v", items)
+	}
+}
+
+func TestDefectsFromMask(t *testing.T) {
+	mask := image.NewAlpha(image.Rect(0, 0, 4, 2))
+	mask.Set(1, 0, color.Alpha{255})
+	mask.Set(2, 0, color.Alpha{255})
+	mask.Set(3, 1, color.Alpha{1})
+
+	defects := DefectsFromMask(mask, 10, 5)
+	if len(defects) != 2 ||
+		defects[0] != (Rect{10, 0, 20, 5}) ||
+		defects[1] != (Rect{30, 5, 10, 5}) {
+		t.Errorf("unexpected defects %v", defects)
+	}
+}
+
+func TestAddDefectMaskUsesPackerCoordinates(t *testing.T) {
+	mask := image.NewAlpha(image.Rect(0, 0, 2, 2))
+	mask.Set(0, 0, color.Alpha{255})
+
+	p := New(20, 20)
+	p.SetOrigin(100, 200)
+	p.AddDefectMask(mask, 10, 10)
+	if r, _ := p.Insert(10, 10); r.Intersects(Rect{100, 200, 10, 10}) {
+		t.Errorf("%v overlaps the defect", r)
+	}
+
+	p = New(20, 20)
+	p.SetYUp(true)
+	p.AddDefectMask(mask, 10, 10)
+	// the top-left cell is at y 10 with Y pointing up
+	if r, _ := p.InsertAtEdge(10, 10, Left|Top); r.Intersects(Rect{0, 10, 10, 10}) {
+		t.Errorf("%v overlaps the defect", r)
+	}
+}
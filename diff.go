@@ -0,0 +1,99 @@
+package binpacker
+
+import "image"
+
+// LayoutDiff describes the changes between two layouts, see Diff.
+type LayoutDiff struct {
+	// Moved holds the indices of rectangles that kept their size but were
+	// placed at a different position.
+	Moved []int
+	// Resized holds the indices of rectangles whose size changed.
+	Resized []int
+	// Added and Removed count the rectangles at the end of the newer or older
+	// layout that have no counterpart in the other one.
+	Added, Removed int
+	// OccupancyDelta is the change in the fraction of the bin covered by
+	// rectangles, padding not included.
+	OccupancyDelta float64
+}
+
+// Diff compares two layouts, e.g. of the previous and current asset build.
+// Rectangles are matched by their insertion index, so both layouts should
+// insert the same items in the same order.
+func Diff(before, after Layout) LayoutDiff {
+	var d LayoutDiff
+	n := min(len(before.Rects), len(after.Rects))
+	for i := 0; i < n; i++ {
+		a, b := before.Rects[i], after.Rects[i]
+		if a.Width != b.Width || a.Height != b.Height {
+			d.Resized = append(d.Resized, i)
+		} else if a != b {
+			d.Moved = append(d.Moved, i)
+		}
+	}
+	d.Added = len(after.Rects) - n
+	d.Removed = len(before.Rects) - n
+	d.OccupancyDelta = coverage(after) - coverage(before)
+	return d
+}
+
+// DirtyRects returns the regions of the bin that changed between two layouts,
+// e.g. to upload only those parts of an atlas texture with glTexSubImage2D
+// after an update. These are the rectangles of after that were added, moved
+// or resized and the places in before that moved, resized or removed
+// rectangles have left. Rectangles are matched by insertion index like in
+// Diff. Existing rectangles can change as well, not only new ones get added,
+// e.g. after Resize, ShrinkTo, SetOrigin, Pool.Rebalance or Enlarge with
+// SetYUp.
+func DirtyRects(before, after Layout) []Rect {
+	var dirty []Rect
+	n := min(len(before.Rects), len(after.Rects))
+	for i := 0; i < n; i++ {
+		if a, b := before.Rects[i], after.Rects[i]; a != b {
+			dirty = append(dirty, a, b)
+		}
+	}
+	dirty = append(dirty, before.Rects[n:]...)
+	dirty = append(dirty, after.Rects[n:]...)
+	return dirty
+}
+
+func coverage(l Layout) float64 {
+	if l.Width*l.Height == 0 {
+		return 0
+	}
+	area := 0
+	for _, r := range l.Rects {
+		area += r.Width * r.Height
+	}
+	return float64(area) / float64(l.Width*l.Height)
+}
+
+// ChangedSprites compares the pixels of the rectangles of two atlases and
+// returns the indices of those whose contents differ, no matter if they moved.
+// Rectangles are matched by insertion index like in Diff, added or removed
+// ones are not included. Resized rectangles always count as changed.
+func ChangedSprites(before, after Layout, beforeImg, afterImg image.Image) []int {
+	var changed []int
+	n := min(len(before.Rects), len(after.Rects))
+	for i := 0; i < n; i++ {
+		a, b := before.Rects[i], after.Rects[i]
+		if a.Width != b.Width || a.Height != b.Height || !samePixels(beforeImg, afterImg, a, b) {
+			changed = append(changed, i)
+		}
+	}
+	return changed
+}
+
+func samePixels(imgA, imgB image.Image, a, b Rect) bool {
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			r1, g1, b1, a1 := imgA.At(a.X+x, a.Y+y).RGBA()
+			r2, g2, b2, a2 := imgB.At(b.X+x, b.Y+y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package binpacker
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := Layout{Width: 10, Height: 10, Rects: []Rect{
+		{0, 0, 5, 5}, {5, 0, 5, 5}, {0, 5, 5, 5}, {5, 5, 5, 5},
+	}}
+	after := Layout{Width: 10, Height: 10, Rects: []Rect{
+		{0, 0, 5, 5}, {0, 5, 5, 5}, {5, 0, 2, 2},
+	}}
+	d := Diff(before, after)
+	if math.Abs(d.OccupancyDelta-(0.54-1)) > 1e-9 {
+		t.Errorf("occupancy delta is %v", d.OccupancyDelta)
+	}
+	d.OccupancyDelta = 0
+	want := LayoutDiff{Moved: []int{1}, Resized: []int{2}, Removed: 1}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("want %+v but have %+v", want, d)
+	}
+}
+
+func TestChangedSprites(t *testing.T) {
+	before := Layout{Width: 4, Height: 2, Rects: []Rect{{0, 0, 2, 2}, {2, 0, 2, 2}}}
+	after := Layout{Width: 4, Height: 2, Rects: []Rect{{2, 0, 2, 2}, {0, 0, 2, 2}}}
+	red := color.RGBA{255, 0, 0, 255}
+
+	beforeImg := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	beforeImg.Set(0, 0, red)
+	afterImg := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	afterImg.Set(2, 0, red) // sprite 0 only moved
+	afterImg.Set(1, 1, red) // sprite 1 changed
+
+	if have := ChangedSprites(before, after, beforeImg, afterImg); !reflect.DeepEqual(have, []int{1}) {
+		t.Errorf("changed sprites: %v", have)
+	}
+}
+
+func TestDirtyRects(t *testing.T) {
+	p := New(20, 10)
+	a, _ := p.Insert(10, 10)
+	p.Tag(a, "a")
+	p.Insert(5, 5)
+	before := p.Snapshot()
+
+	resized, _, _ := p.Resize("a", 5, 5)
+	added, _ := p.Insert(5, 5)
+	dirty := DirtyRects(before, p.Snapshot())
+	want := []Rect{a, resized, added}
+	if !reflect.DeepEqual(dirty, want) {
+		t.Errorf("want %v but have %v", want, dirty)
+	}
+	if d := DirtyRects(p.Snapshot(), p.Snapshot()); len(d) != 0 {
+		t.Errorf("unchanged layout has dirty rects %v", d)
+	}
+}
@@ -0,0 +1,33 @@
+package binpacker
+
+// CanFitAll tells whether all rectangles of the given sizes would fit into the
+// bin if they were inserted in order with Insert, including the limit set with
+// SetMaxOccupancy. The packer is not changed.
+func (p *Packer) CanFitAll(sizes []Size) bool {
+	root := copyTree(&p.root)
+	added := 0
+	for _, s := range sizes {
+		w, h, _ := p.regionSize(s.Width, s.Height, p.padding)
+		added += w * h
+		if p.exceedsMaxOccupancy(added) {
+			return false
+		}
+		if len(p.defects) > 0 {
+			n, right, bottom := findFree(root, w, h, p.defects)
+			if n == nil {
+				return false
+			}
+			split(n, w, h, right, bottom)
+		} else if _, err := insert(root, w, h); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func copyTree(n *node) *node {
+	if n == nil {
+		return nil
+	}
+	return &node{Rect: n.Rect, left: copyTree(n.left), right: copyTree(n.right)}
+}
@@ -0,0 +1,21 @@
+package binpacker
+
+import "testing"
+
+func TestCanFitAll(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(5, 10)
+
+	if !p.CanFitAll([]Size{{5, 5}, {5, 5}}) {
+		t.Error("two 5x5 rects should fit")
+	}
+	if p.CanFitAll([]Size{{5, 5}, {5, 5}, {1, 1}}) {
+		t.Error("a third rect should not fit")
+	}
+	if p.Occupancy() != 0.5 || p.inserts != 1 {
+		t.Error("CanFitAll changed the packer")
+	}
+	if _, err := p.Insert(5, 10); err != nil {
+		t.Error("free space was used up by CanFitAll")
+	}
+}
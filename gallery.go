@@ -0,0 +1,75 @@
+package binpacker
+
+// JustifiedRows lays out items, e.g. photos, in rows that fill the given width
+// exactly, keeping the items' order and aspect ratios. Items are scaled to
+// rowHeight and as many as fit are put in a row, then the row is scaled so it
+// is exactly width wide. The last row is not scaled up. spacing is the gap
+// between items and rows. The returned rects are in the order of sizes.
+func JustifiedRows(width, rowHeight, spacing int, sizes []Size) []Rect {
+	rects := make([]Rect, len(sizes))
+	scaledWidth := func(s Size, h float64) float64 {
+		if s.Height == 0 {
+			return 0
+		}
+		return float64(s.Width) * h / float64(s.Height)
+	}
+
+	y := 0
+	for start := 0; start < len(sizes); {
+		// Take items while the row at rowHeight still fits.
+		end, sum := start, 0.0
+		for end < len(sizes) {
+			w := scaledWidth(sizes[end], float64(rowHeight))
+			gaps := float64(spacing * (end - start))
+			if end > start && sum+w+gaps > float64(width) {
+				break
+			}
+			sum += w
+			end++
+		}
+
+		free := float64(width - spacing*(end-start-1))
+		h := float64(rowHeight)
+		if end < len(sizes) && sum > 0 {
+			h = h * free / sum // justify all but the last row
+		}
+		x := 0.0
+		for i := start; i < end; i++ {
+			left := int(x + 0.5)
+			x += scaledWidth(sizes[i], h)
+			rects[i] = Rect{X: left, Y: y, Width: int(x+0.5) - left, Height: int(h + 0.5)}
+			x += float64(spacing)
+		}
+		y += int(h+0.5) + spacing
+		start = end
+	}
+	return rects
+}
+
+// Masonry lays out items in the given number of columns of equal width, each
+// item scaled to the column width and put at the bottom of the currently
+// shortest column. spacing is the gap between columns and items. The returned
+// rects are in the order of sizes.
+func Masonry(width, columns, spacing int, sizes []Size) []Rect {
+	rects := make([]Rect, len(sizes))
+	if columns <= 0 {
+		return rects
+	}
+	columnW := (width - spacing*(columns-1)) / columns
+	heights := make([]int, columns)
+	for i, s := range sizes {
+		col := 0
+		for c := range heights {
+			if heights[c] < heights[col] {
+				col = c
+			}
+		}
+		h := 0
+		if s.Width > 0 {
+			h = (s.Height*columnW + s.Width/2) / s.Width
+		}
+		rects[i] = Rect{X: col * (columnW + spacing), Y: heights[col], Width: columnW, Height: h}
+		heights[col] += h + spacing
+	}
+	return rects
+}
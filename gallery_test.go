@@ -0,0 +1,34 @@
+package binpacker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJustifiedRows(t *testing.T) {
+	sizes := []Size{{40, 20}, {40, 20}, {20, 20}, {60, 20}, {20, 20}}
+	rects := JustifiedRows(100, 20, 0, sizes)
+	want := []Rect{
+		// 40+40+20 fill the width at the target height
+		{0, 0, 40, 20}, {40, 0, 40, 20}, {80, 0, 20, 20},
+		// the last row is not stretched
+		{0, 20, 60, 20}, {60, 20, 20, 20},
+	}
+	if !reflect.DeepEqual(rects, want) {
+		t.Errorf("want %v but have %v", want, rects)
+	}
+
+	// 30+30 does not fit with the next 60, so the row is stretched to 100
+	rects = JustifiedRows(100, 10, 0, []Size{{30, 10}, {30, 10}, {60, 10}})
+	if rects[1] != (Rect{50, 0, 50, 17}) || rects[2].Y != 17 {
+		t.Errorf("row was not justified: %v", rects)
+	}
+}
+
+func TestMasonry(t *testing.T) {
+	rects := Masonry(100, 2, 10, []Size{{90, 90}, {45, 15}, {45, 15}})
+	want := []Rect{{0, 0, 45, 45}, {55, 0, 45, 15}, {55, 25, 45, 15}}
+	if !reflect.DeepEqual(rects, want) {
+		t.Errorf("want %v but have %v", want, rects)
+	}
+}
@@ -0,0 +1,61 @@
+package binpacker
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+)
+
+// WriteGoSource writes a Go source file to w that declares the bin size and
+// all inserted rectangles with their texture coordinates (see UV), so the
+// layout can be compiled into a program. The file belongs to the given package
+// and, if buildTag is not empty, is only built with that build tag. The
+// rectangles are in insertion order and can also be looked up by their tags.
+func (p *Packer) WriteGoSource(w io.Writer, pkg, buildTag string) error {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by binpacker. DO NOT EDIT.\n\n")
+	if buildTag != "" {
+		fmt.Fprintf(&b, "// +build %s\n\n", buildTag)
+	}
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("// Sprite is a rectangle of the atlas and its texture coordinates.\n")
+	b.WriteString("type Sprite struct {\nX, Y, Width, Height int\nU0, V0, U1, V1 float32\n}\n\n")
+	fmt.Fprintf(&b, "const (\nAtlasWidth = %d\nAtlasHeight = %d\n)\n\n", p.binWidth, p.binHeight)
+
+	b.WriteString("// Sprites holds all sprites in insertion order.\n")
+	b.WriteString("var Sprites = [...]Sprite{\n")
+	for _, it := range p.items {
+		r := p.flip(it.Rect)
+		u0, v0, u1, v1 := p.UV(r)
+		fmt.Fprintf(&b, "{%d, %d, %d, %d, %s, %s, %s, %s},\n",
+			r.X, r.Y, r.Width, r.Height,
+			float32Literal(u0), float32Literal(v0), float32Literal(u1), float32Literal(v1))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// SpriteIndex maps tags to the index of the first sprite with that tag.\n")
+	b.WriteString("var SpriteIndex = map[string]int{\n")
+	seen := make(map[string]bool)
+	for i, it := range p.items {
+		for _, t := range it.tags {
+			if !seen[t] {
+				seen[t] = true
+				fmt.Fprintf(&b, "%q: %d,\n", t, i)
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+func float32Literal(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 32)
+}
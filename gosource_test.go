@@ -0,0 +1,36 @@
+package binpacker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteGoSource(t *testing.T) {
+	p := New(4, 2)
+	a, _ := p.Insert(2, 2)
+	b, _ := p.Insert(1, 1)
+	p.Tag(a, "player")
+	p.Tag(b, "coin", "player")
+
+	var buf bytes.Buffer
+	if err := p.WriteGoSource(&buf, "atlas", "embed"); err != nil {
+		t.Fatal(err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		"// +build embed\n\npackage atlas\n",
+		"AtlasWidth  = 4\n",
+		"{0, 0, 2, 2, 0, 0, 0.5, 1},\n",
+		"{2, 0, 1, 1, 0.5, 0, 0.75, 0.5},\n",
+		"\"player\": 0,\n",
+		"\"coin\":   1,\n",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %q in\n%s", want, src)
+		}
+	}
+	if strings.Count(src, "\"player\"") != 1 {
+		t.Error("duplicate tag in SpriteIndex")
+	}
+}
@@ -0,0 +1,133 @@
+package binpacker
+
+import "errors"
+
+// Grid allocates rectangles of whole cells in a grid of columns x rows cells,
+// e.g. widgets on a dashboard. Unlike Packer, rectangles can be removed and
+// moved. All rects are given in cells.
+type Grid struct {
+	columns, rows int
+	cells         []int // index+1 of the rect covering the cell, 0 if free
+	rects         []Rect
+}
+
+// NewGrid creates an empty grid.
+func NewGrid(columns, rows int) *Grid {
+	return &Grid{
+		columns: columns,
+		rows:    rows,
+		cells:   make([]int, columns*rows),
+	}
+}
+
+// Insert places a rectangle of the given size at the first free position,
+// scanning the rows from top to bottom and each row from left to right.
+func (g *Grid) Insert(width, height int) (Rect, error) {
+	for y := 0; y+height <= g.rows; y++ {
+		for x := 0; x+width <= g.columns; x++ {
+			r := Rect{X: x, Y: y, Width: width, Height: height}
+			if g.free(r, -1) {
+				g.set(r, len(g.rects)+1)
+				g.rects = append(g.rects, r)
+				return r, nil
+			}
+		}
+	}
+	return Rect{}, ErrNoMoreSpace
+}
+
+// Place puts a rectangle at a given position, e.g. to restore a saved layout.
+// It fails if r is outside the grid or collides with another rectangle.
+func (g *Grid) Place(r Rect) error {
+	if !g.free(r, -1) {
+		return errors.New("place: rect is not free")
+	}
+	g.set(r, len(g.rects)+1)
+	g.rects = append(g.rects, r)
+	return nil
+}
+
+// Remove frees the cells of a rectangle that was inserted or placed.
+func (g *Grid) Remove(r Rect) error {
+	i := g.index(r)
+	if i == -1 {
+		return errors.New("remove: rect is not in the grid")
+	}
+	g.set(r, 0)
+	last := len(g.rects) - 1
+	if i != last {
+		g.rects[i] = g.rects[last]
+		g.set(g.rects[i], i+1)
+	}
+	g.rects = g.rects[:last]
+	return nil
+}
+
+// Move moves a rectangle so its top-left cell is at (x, y). It fails if the
+// new position is outside the grid or collides with another rectangle.
+func (g *Grid) Move(r Rect, x, y int) (Rect, error) {
+	i := g.index(r)
+	if i == -1 {
+		return r, errors.New("move: rect is not in the grid")
+	}
+	to := Rect{X: x, Y: y, Width: r.Width, Height: r.Height}
+	if !g.free(to, i) {
+		return r, errors.New("move: target is not free")
+	}
+	g.set(r, 0)
+	g.set(to, i+1)
+	g.rects[i] = to
+	return to, nil
+}
+
+// Collisions returns all rectangles in the grid that overlap r.
+func (g *Grid) Collisions(r Rect) []Rect {
+	var hits []Rect
+	for _, other := range g.rects {
+		if r.Intersects(other) {
+			hits = append(hits, other)
+		}
+	}
+	return hits
+}
+
+// Rects returns all rectangles in the grid, e.g. to save the layout.
+func (g *Grid) Rects() []Rect {
+	return append([]Rect(nil), g.rects...)
+}
+
+// free tells whether r lies in the grid and all its cells are free or belong
+// to the rect with the given index.
+func (g *Grid) free(r Rect, index int) bool {
+	if r.X < 0 || r.Y < 0 || r.Width <= 0 || r.Height <= 0 ||
+		r.X+r.Width > g.columns || r.Y+r.Height > g.rows {
+		return false
+	}
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		for x := r.X; x < r.X+r.Width; x++ {
+			if c := g.cells[y*g.columns+x]; c != 0 && c != index+1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (g *Grid) set(r Rect, value int) {
+	for y := r.Y; y < r.Y+r.Height; y++ {
+		for x := r.X; x < r.X+r.Width; x++ {
+			g.cells[y*g.columns+x] = value
+		}
+	}
+}
+
+func (g *Grid) index(r Rect) int {
+	if r.X < 0 || r.Y < 0 || r.X >= g.columns || r.Y >= g.rows {
+		return -1
+	}
+	i := g.cells[r.Y*g.columns+r.X] - 1
+	if i == -1 || g.rects[i] != r {
+		return -1
+	}
+	return i
+}
@@ -0,0 +1,46 @@
+package binpacker
+
+import "testing"
+
+func TestGrid(t *testing.T) {
+	g := NewGrid(12, 4)
+	a, _ := g.Insert(6, 2)
+	b, _ := g.Insert(6, 2)
+	c, _ := g.Insert(4, 1)
+	if a != (Rect{0, 0, 6, 2}) || b != (Rect{6, 0, 6, 2}) || c != (Rect{0, 2, 4, 1}) {
+		t.Fatalf("placed at %v %v %v", a, b, c)
+	}
+
+	if _, err := g.Move(c, 4, 1); err == nil {
+		t.Error("moved onto another rect")
+	}
+	c, err := g.Move(c, 2, 2) // overlaps its old position
+	if err != nil || c != (Rect{2, 2, 4, 1}) {
+		t.Errorf("move gave %v, %v", c, err)
+	}
+
+	if hits := g.Collisions(Rect{5, 1, 2, 2}); len(hits) != 3 {
+		t.Errorf("collisions: %v", hits)
+	}
+
+	if err := g.Remove(a); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Remove(a); err == nil {
+		t.Error("removed twice")
+	}
+	if r, _ := g.Insert(6, 2); r != a {
+		t.Errorf("freed space was not reused: %v", r)
+	}
+	if err := g.Place(Rect{10, 3, 3, 1}); err == nil {
+		t.Error("placed outside the grid")
+	}
+	if n := len(g.Rects()); n != 3 {
+		t.Errorf("grid has %d rects", n)
+	}
+	for _, r := range g.Rects() {
+		if err := g.Remove(r); err != nil {
+			t.Errorf("remove %v: %v", r, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package binpacker
+
+import (
+	"errors"
+	"math"
+)
+
+// InsertGroup packs rectangles of the given sizes into a compact group region
+// and inserts that region into the bin as a whole, so the group's rectangles
+// stay next to each other, e.g. all decals of one model. Inside the group the
+// rectangles keep the packer's padding and alignment. InsertGroup returns the
+// group's region and the rectangles in the order of sizes. The packer tracks
+// the group as a single inserted rectangle. An empty group is an error.
+func (p *Packer) InsertGroup(sizes []Size) (group Rect, rects []Rect, err error) {
+	if len(sizes) == 0 {
+		return Rect{}, nil, errors.New("insert group: no sizes given")
+	}
+	items := make([]item, len(sizes))
+	area, widest, totalW, totalH := 0, 0, 0, 0
+	for i, s := range sizes {
+		w, h, padding := p.regionSize(s.Width, s.Height, p.padding)
+		items[i] = item{
+			Rect:   Rect{X: padding, Y: padding, Width: s.Width, Height: s.Height},
+			region: Rect{Width: w, Height: h},
+		}
+		area += w * h
+		widest = max(widest, w)
+		totalW += w
+		totalH += h
+	}
+
+	// Try a few group widths between the widest item and a single row and
+	// keep the one with the smallest bounding box.
+	var packed []item
+	groupW, groupH := 0, 0
+	side := math.Sqrt(float64(area))
+	for _, f := range []float64{1, 1.25, 1.5, 2} {
+		w := min(max(widest, int(math.Ceil(side*f))), totalW)
+		root := node{Rect: Rect{Width: w, Height: totalH}}
+		moved, ok := repack(&root, items, nil)
+		if !ok {
+			continue
+		}
+		usedW, usedH := 0, 0
+		for _, it := range moved {
+			usedW = max(usedW, it.region.X+it.region.Width)
+			usedH = max(usedH, it.region.Y+it.region.Height)
+		}
+		if packed == nil || usedW*usedH < groupW*groupH {
+			packed, groupW, groupH = moved, usedW, usedH
+		}
+	}
+
+	if packed == nil {
+		return Rect{}, nil, ErrNoMoreSpace
+	}
+
+	group, err = p.InsertPadded(groupW, groupH, 0)
+	if err != nil {
+		return Rect{}, nil, err
+	}
+	origin := p.flip(group)
+	rects = make([]Rect, len(packed))
+	for i, it := range packed {
+		it.X += origin.X
+		it.Y += origin.Y
+		rects[i] = p.flip(it.Rect)
+	}
+	return group, rects, nil
+}
@@ -0,0 +1,43 @@
+package binpacker
+
+import "testing"
+
+func TestInsertGroup(t *testing.T) {
+	p := New(100, 100)
+	p.Insert(30, 30)
+
+	sizes := []Size{{10, 10}, {10, 10}, {10, 10}, {10, 10}}
+	group, rects, err := p.InsertGroup(sizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group != (Rect{0, 30, 20, 20}) {
+		t.Errorf("group region is %v", group)
+	}
+	for i, r := range rects {
+		if r.Width != 10 || r.Height != 10 ||
+			r.X < group.X || r.Y < group.Y ||
+			r.X+r.Width > group.X+group.Width || r.Y+r.Height > group.Y+group.Height {
+			t.Errorf("rect %d at %v is not inside its group", i, r)
+		}
+		for j := 0; j < i; j++ {
+			if r.Intersects(rects[j]) {
+				t.Errorf("rects %d and %d overlap", i, j)
+			}
+		}
+	}
+
+	if _, _, err := p.InsertGroup([]Size{{80, 80}}); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace but have %v", err)
+	}
+}
+
+func TestInsertGroupRejectsEmptyGroups(t *testing.T) {
+	p := New(100, 100)
+	if _, _, err := p.InsertGroup(nil); err == nil {
+		t.Error("want error for an empty group")
+	}
+	if s := p.Stats(); s.Inserts != 0 {
+		t.Errorf("empty group was inserted: %+v", s)
+	}
+}
@@ -0,0 +1,234 @@
+package binpacker
+
+import "errors"
+
+// InsertGrow works like Insert but enlarges the bin if the rectangle does not
+// fit. The new bin size is computed by the packer's GrowthPolicy, see
+// SetGrowthPolicy, and adjusted to the aspect ratio set with SetAspectRatio.
+// If the policy gives up, ErrNoMoreSpace is returned and the bin is unchanged.
+// The bin never grows beyond the size set with SetMaxSize, if the rectangle
+// does not fit at that size, ErrMaxSize is returned.
+func (p *Packer) InsertGrow(width, height int) (Rect, error) {
+	r, err := p.Insert(width, height)
+	if err != ErrNoMoreSpace {
+		return r, err
+	}
+
+	policy := p.growth
+	if policy == nil {
+		policy = DoubleBoth
+	}
+	paddedW, paddedH, _ := p.regionSize(width, height, p.padding)
+	w, h := p.binWidth, p.binHeight
+	for !p.fitsAfterEnlarge(w, h, paddedW, paddedH) {
+		newW, newH, ok := policy.Grow(w, h, paddedW, paddedH)
+		if !ok || newW < w || newH < h || newW == w && newH == h {
+			return Rect{}, ErrNoMoreSpace
+		}
+		newW, newH = p.limitSize(p.keepAspect(newW, newH))
+		if newW == w && newH == h {
+			return Rect{}, ErrMaxSize
+		}
+		w, h = newW, newH
+	}
+	if err := p.Enlarge(w, h); err != nil {
+		return Rect{}, err
+	}
+	return p.Insert(width, height)
+}
+
+// SuggestEnlarge returns the smallest bin size to pass to Enlarge so that a
+// rectangle of the given size can be inserted afterwards. The size respects
+// the aspect ratio set with SetAspectRatio. If the rectangle already fits, the
+// current size is returned. The suggested bin is large enough to stay within
+// the limit set with SetMaxOccupancy and to place the rectangle around the
+// defects added with AddDefects. ok is false if the rectangle would only fit
+// into a bin larger than the size set with SetMaxSize.
+func (p *Packer) SuggestEnlarge(width, height int) (newWidth, newHeight int, ok bool) {
+	if p.CanFitAll([]Size{{width, height}}) {
+		return p.binWidth, p.binHeight, true
+	}
+	w, h, _ := p.regionSize(width, height, p.padding)
+	candidates := [][2]int{
+		{max(p.binWidth, w), p.binHeight + h}, // below the current area
+		{p.binWidth + w, max(p.binHeight, h)}, // right of the current area
+	}
+	// After Enlarge the whole previous bin counts as used, see Occupancy.
+	used := p.binWidth*p.binHeight + w*h
+	for i, c := range candidates {
+		cw, ch := p.keepAspect(c[0], c[1])
+		if p.exceedsMaxOccupancyOf(used, cw*ch) {
+			// Grow in the candidate's direction until the occupancy is low
+			// enough.
+			minArea := ceilDiv(used*100, p.maxOccupancy)
+			if i == 0 {
+				ch = ceilDiv(minArea, cw)
+			} else {
+				cw = ceilDiv(minArea, ch)
+			}
+			cw, ch = p.keepAspect(cw, ch)
+		}
+		cw, ch = p.limitSize(cw, ch)
+		// Defects in the added area can block the rectangle, keep growing
+		// until it gets past them. The area right of the current bin only
+		// gets wider, so that only helps if the rectangle is not too high.
+		for (i == 0 || h <= p.binHeight) && !p.fitsAfterEnlarge(cw, ch, w, h) {
+			nextW, nextH := cw, ch+1
+			if i == 1 {
+				nextW, nextH = cw+1, ch
+			}
+			nextW, nextH = p.limitSize(p.keepAspect(nextW, nextH))
+			if nextW == cw && nextH == ch {
+				break
+			}
+			cw, ch = nextW, nextH
+		}
+		if !p.fitsAfterEnlarge(cw, ch, w, h) || p.exceedsMaxOccupancyOf(used, cw*ch) {
+			continue
+		}
+		if !ok || cw*ch < newWidth*newHeight {
+			newWidth, newHeight, ok = cw, ch, true
+		}
+	}
+	return
+}
+
+// Common maximum texture sizes of GPUs, for use with SetMaxSize.
+const (
+	Limit2K  = 2048
+	Limit4K  = 4096
+	Limit8K  = 8192
+	Limit16K = 16384
+)
+
+// ErrMaxSize is returned when the bin would have to grow beyond the size set
+// with SetMaxSize. Callers should start a new bin instead.
+var ErrMaxSize = errors.New("enlarge: bin would exceed its maximum size")
+
+// SetMaxSize limits the size that Enlarge and InsertGrow can grow the bin to,
+// e.g. to the maximum texture size of the target GPU. A limit <= 0 means the
+// width or height can grow without limit, which is the default.
+func (p *Packer) SetMaxSize(width, height int) {
+	p.maxW, p.maxH = width, height
+}
+
+// limitSize clamps the given bin size to the maximum size but never shrinks
+// the bin.
+func (p *Packer) limitSize(w, h int) (int, int) {
+	if p.maxW > 0 && w > p.maxW {
+		w = max(p.maxW, p.binWidth)
+	}
+	if p.maxH > 0 && h > p.maxH {
+		h = max(p.maxH, p.binHeight)
+	}
+	return w, h
+}
+
+// GrowthPolicy decides how InsertGrow enlarges a full bin. Grow gets the current
+// bin size and the size of the rectangle that does not fit and returns the next
+// bin size to try. If the rectangle does not fit into that size either, Grow is
+// called again with the new size. Returning ok == false gives up.
+type GrowthPolicy interface {
+	Grow(binWidth, binHeight, width, height int) (newWidth, newHeight int, ok bool)
+}
+
+// GrowthFunc adapts a function to the GrowthPolicy interface.
+type GrowthFunc func(binWidth, binHeight, width, height int) (newWidth, newHeight int, ok bool)
+
+func (f GrowthFunc) Grow(binWidth, binHeight, width, height int) (int, int, bool) {
+	return f(binWidth, binHeight, width, height)
+}
+
+var (
+	// DoubleBoth doubles the bin's width and height. This is the default.
+	DoubleBoth GrowthPolicy = GrowthFunc(func(binW, binH, _, _ int) (int, int, bool) {
+		return double(binW), double(binH), true
+	})
+
+	// DoubleShorterSide doubles only the smaller of the bin's width and
+	// height, the width if they are equal.
+	DoubleShorterSide GrowthPolicy = GrowthFunc(func(binW, binH, _, _ int) (int, int, bool) {
+		if binW <= binH {
+			return double(binW), binH, true
+		}
+		return binW, double(binH), true
+	})
+
+	// NextPowerOfTwo grows the bin just enough for the rectangle to fit next to
+	// or below the current area and rounds the result up to powers of two.
+	NextPowerOfTwo GrowthPolicy = GrowthFunc(func(binW, binH, w, h int) (int, int, bool) {
+		rightW, rightH := nextPowerOfTwo(binW+w), nextPowerOfTwo(max(binH, h))
+		belowW, belowH := nextPowerOfTwo(max(binW, w)), nextPowerOfTwo(binH+h)
+		if rightW*rightH <= belowW*belowH {
+			return rightW, rightH, true
+		}
+		return belowW, belowH, true
+	})
+)
+
+// GrowBy returns a GrowthPolicy that adds a fixed amount to the bin's width
+// and height.
+func GrowBy(width, height int) GrowthPolicy {
+	return GrowthFunc(func(binW, binH, _, _ int) (int, int, bool) {
+		return binW + width, binH + height, width > 0 || height > 0
+	})
+}
+
+// SetGrowthPolicy sets the policy that InsertGrow uses to enlarge the bin. A
+// nil policy means DoubleBoth.
+func (p *Packer) SetGrowthPolicy(g GrowthPolicy) {
+	p.growth = g
+}
+
+// SetAspectRatio makes InsertGrow choose bin sizes of the given proportions,
+// e.g. 1:1 for square or 2:1 for twice as wide as high bins. The width or
+// height are increased as necessary to keep the ratio. Call SetAspectRatio(0, 0)
+// to let the bin grow freely again.
+func (p *Packer) SetAspectRatio(width, height int) {
+	p.aspectW, p.aspectH = width, height
+}
+
+func (p *Packer) keepAspect(w, h int) (int, int) {
+	if p.aspectW <= 0 || p.aspectH <= 0 {
+		return w, h
+	}
+	if w*p.aspectH < h*p.aspectW {
+		w = (h*p.aspectW + p.aspectH - 1) / p.aspectH
+	} else {
+		h = (w*p.aspectH + p.aspectW - 1) / p.aspectW
+	}
+	return w, h
+}
+
+// fitsAfterEnlarge tells whether a rectangle of the given size fits into the
+// area that Enlarge(newW, newH) adds to the bin without overlapping a defect.
+func (p *Packer) fitsAfterEnlarge(newW, newH, width, height int) bool {
+	if newW < p.binWidth || newH < p.binHeight {
+		return false
+	}
+	root := p.enlargedRoot(newW, newH)
+	n, _, _ := findFree(&root, width, height, p.defects)
+	return n != nil
+}
+
+func double(x int) int {
+	if x <= 0 {
+		return 1
+	}
+	return 2 * x
+}
+
+func nextPowerOfTwo(x int) int {
+	n := 1
+	for n < x {
+		n *= 2
+	}
+	return n
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,149 @@
+package binpacker
+
+import "testing"
+
+func TestInsertGrowDoublesBin(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(10, 10)
+	r, err := p.InsertGrow(10, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.binWidth != 20 || p.binHeight != 20 {
+		t.Errorf("want 20x20 bin but have %dx%d", p.binWidth, p.binHeight)
+	}
+	if r != (Rect{0, 10, 10, 10}) {
+		t.Errorf("unexpected placement %v", r)
+	}
+}
+
+func TestInsertGrowKeepsAspectRatio(t *testing.T) {
+	p := New(10, 10)
+	p.SetAspectRatio(2, 1)
+	p.Insert(10, 10)
+	if _, err := p.InsertGrow(10, 10); err != nil {
+		t.Fatal(err)
+	}
+	if p.binWidth != 40 || p.binHeight != 20 {
+		t.Errorf("want 40x20 bin but have %dx%d", p.binWidth, p.binHeight)
+	}
+}
+
+func TestGrowthPolicies(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       GrowthPolicy
+		wantW, wantH int
+	}{
+		{"DoubleBoth", DoubleBoth, 20, 20},
+		{"DoubleShorterSide", DoubleShorterSide, 20, 10},
+		{"GrowBy", GrowBy(3, 0), 16, 10},
+		{"NextPowerOfTwo", NextPowerOfTwo, 16, 16},
+	}
+	for _, tt := range tests {
+		p := New(10, 10)
+		p.SetGrowthPolicy(tt.policy)
+		p.Insert(10, 10)
+		if _, err := p.InsertGrow(4, 10); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if p.binWidth != tt.wantW || p.binHeight != tt.wantH {
+			t.Errorf("%s: want %dx%d but have %dx%d",
+				tt.name, tt.wantW, tt.wantH, p.binWidth, p.binHeight)
+		}
+	}
+}
+
+func TestInsertGrowStopsWhenPolicyGivesUp(t *testing.T) {
+	p := New(10, 10)
+	p.SetGrowthPolicy(GrowBy(0, 0))
+	p.Insert(10, 10)
+	if _, err := p.InsertGrow(1, 1); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace but have %v", err)
+	}
+}
+
+func TestMaxSizeLimitsGrowth(t *testing.T) {
+	p := New(1024, 1024)
+	p.SetMaxSize(Limit2K, Limit2K)
+	if err := p.Enlarge(Limit4K, Limit2K); err != ErrMaxSize {
+		t.Errorf("want ErrMaxSize from Enlarge but have %v", err)
+	}
+
+	p.Insert(1024, 1024)
+	p.SetGrowthPolicy(GrowBy(700, 700))
+	if _, err := p.InsertGrow(1024, 1024); err != nil {
+		t.Fatal(err)
+	}
+	if p.binWidth != Limit2K || p.binHeight != Limit2K {
+		t.Errorf("want bin clamped to 2K but have %dx%d", p.binWidth, p.binHeight)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := p.InsertGrow(1024, 1024); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := p.InsertGrow(1024, 1024); err != ErrMaxSize {
+		t.Errorf("want ErrMaxSize from InsertGrow but have %v", err)
+	}
+}
+
+func TestSuggestEnlarge(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(10, 8)
+
+	if w, h, ok := p.SuggestEnlarge(10, 2); !ok || w != 10 || h != 10 {
+		t.Errorf("fitting rect: got %dx%d %v, want current size", w, h, ok)
+	}
+	if w, h, ok := p.SuggestEnlarge(4, 3); !ok || w != 10 || h != 13 {
+		t.Errorf("got %dx%d %v, want 10x13", w, h, ok)
+	}
+	if w, h, ok := p.SuggestEnlarge(12, 3); !ok || w != 12 || h != 13 {
+		t.Errorf("got %dx%d %v, want 12x13", w, h, ok)
+	}
+	w, h, _ := p.SuggestEnlarge(4, 3)
+	p.Enlarge(w, h)
+	if _, err := p.Insert(4, 3); err != nil {
+		t.Error("rect does not fit after suggested enlargement")
+	}
+
+	p.SetMaxSize(12, 12)
+	if _, _, ok := p.SuggestEnlarge(20, 20); ok {
+		t.Error("suggestion should exceed the maximum size")
+	}
+}
+
+func TestSuggestEnlargeAvoidsDefects(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(10, 10)
+	p.AddDefects(Rect{0, 10, 10, 2})
+	if w, h, ok := p.SuggestEnlarge(10, 3); !ok || w != 10 || h != 15 {
+		t.Errorf("got %dx%d %v, want 10x15", w, h, ok)
+	}
+
+	for x := 0; x < 20; x += 3 {
+		for y := 0; y < 20; y += 3 {
+			for size := 1; size < 12; size += 2 {
+				defect := Rect{x, y, 3, 3}
+				p := New(10, 10)
+				p.Insert(10, 10)
+				p.AddDefects(defect)
+				w, h, ok := p.SuggestEnlarge(size, size)
+				if !ok {
+					t.Fatalf("%v, %d: no suggestion", defect, size)
+				}
+				p.Enlarge(w, h)
+				if _, err := p.Insert(size, size); err != nil {
+					t.Errorf("%v, %d: rect does not fit into suggested %dx%d", defect, size, w, h)
+				}
+
+				p = New(10, 10)
+				p.Insert(10, 10)
+				p.AddDefects(defect)
+				if _, err := p.InsertGrow(size, size); err != nil {
+					t.Errorf("%v, %d: InsertGrow failed: %v", defect, size, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package binpacker
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+)
+
+// Frame is a sprite read from the metadata of an atlas made by another tool,
+// e.g. to repack it or merge several atlases into one.
+type Frame struct {
+	Name string
+	// Rect is the sprite's area in the sheet as written in the metadata.
+	Rect Rect
+	// Rotated is true if the sprite is stored rotated by 90 degrees.
+	Rotated bool
+	// Source is the size of the sprite before transparent borders were
+	// trimmed and (OffsetX, OffsetY) is where the trimmed part starts in it.
+	Source           Size
+	OffsetX, OffsetY int
+}
+
+// ReadTexturePackerJSON reads the frames of a TexturePacker JSON file, in
+// either the hash or the array format. Frames of the hash format are sorted by
+// name.
+func ReadTexturePackerJSON(r io.Reader) ([]Frame, error) {
+	type xywh struct{ X, Y, W, H int }
+	type tpFrame struct {
+		Filename         string
+		Frame            xywh
+		Rotated          bool
+		SpriteSourceSize xywh
+		SourceSize       xywh
+	}
+	var file struct {
+		Frames json.RawMessage
+	}
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+
+	var list []tpFrame
+	if err := json.Unmarshal(file.Frames, &list); err != nil {
+		var hash map[string]tpFrame
+		if json.Unmarshal(file.Frames, &hash) != nil {
+			return nil, errors.New("texture packer: frames are neither an array nor a hash")
+		}
+		for name, f := range hash {
+			f.Filename = name
+			list = append(list, f)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Filename < list[j].Filename })
+	}
+
+	frames := make([]Frame, len(list))
+	for i, f := range list {
+		frames[i] = Frame{
+			Name:    f.Filename,
+			Rect:    Rect{X: f.Frame.X, Y: f.Frame.Y, Width: f.Frame.W, Height: f.Frame.H},
+			Rotated: f.Rotated,
+			Source:  Size{Width: f.SourceSize.W, Height: f.SourceSize.H},
+			OffsetX: f.SpriteSourceSize.X,
+			OffsetY: f.SpriteSourceSize.Y,
+		}
+		if frames[i].Source == (Size{}) {
+			frames[i].Source = Size{Width: f.Frame.W, Height: f.Frame.H}
+		}
+	}
+	return frames, nil
+}
+
+// ReadSparrowXML reads the frames of a Sparrow/Starling texture atlas XML
+// file, in file order.
+func ReadSparrowXML(r io.Reader) ([]Frame, error) {
+	var atlas struct {
+		SubTextures []struct {
+			Name        string `xml:"name,attr"`
+			X           int    `xml:"x,attr"`
+			Y           int    `xml:"y,attr"`
+			Width       int    `xml:"width,attr"`
+			Height      int    `xml:"height,attr"`
+			FrameX      int    `xml:"frameX,attr"`
+			FrameY      int    `xml:"frameY,attr"`
+			FrameWidth  int    `xml:"frameWidth,attr"`
+			FrameHeight int    `xml:"frameHeight,attr"`
+			Rotated     bool   `xml:"rotated,attr"`
+		} `xml:"SubTexture"`
+	}
+	if err := xml.NewDecoder(r).Decode(&atlas); err != nil {
+		return nil, err
+	}
+	frames := make([]Frame, len(atlas.SubTextures))
+	for i, t := range atlas.SubTextures {
+		frames[i] = Frame{
+			Name:    t.Name,
+			Rect:    Rect{X: t.X, Y: t.Y, Width: t.Width, Height: t.Height},
+			Rotated: t.Rotated,
+			Source:  Size{Width: t.FrameWidth, Height: t.FrameHeight},
+			// Sparrow stores where the source starts relative to the trimmed
+			// part, which is the negative offset.
+			OffsetX: -t.FrameX,
+			OffsetY: -t.FrameY,
+		}
+		if frames[i].Source == (Size{}) {
+			frames[i].Source = Size{Width: t.Width, Height: t.Height}
+		}
+	}
+	return frames, nil
+}
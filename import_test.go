@@ -0,0 +1,55 @@
+package binpacker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadTexturePackerJSON(t *testing.T) {
+	want := []Frame{
+		{Name: "a.png", Rect: Rect{0, 0, 10, 8}, Source: Size{12, 10}, OffsetX: 1, OffsetY: 2},
+		{Name: "b.png", Rect: Rect{10, 0, 4, 4}, Rotated: true, Source: Size{4, 4}},
+	}
+	for _, file := range []string{
+		`{"frames": {
+			"b.png": {"frame": {"x":10,"y":0,"w":4,"h":4}, "rotated": true},
+			"a.png": {"frame": {"x":0,"y":0,"w":10,"h":8}, "trimmed": true,
+				"spriteSourceSize": {"x":1,"y":2,"w":10,"h":8},
+				"sourceSize": {"w":12,"h":10}}
+		}, "meta": {"image": "sheet.png"}}`,
+		`{"frames": [
+			{"filename": "a.png", "frame": {"x":0,"y":0,"w":10,"h":8},
+				"spriteSourceSize": {"x":1,"y":2,"w":10,"h":8},
+				"sourceSize": {"w":12,"h":10}},
+			{"filename": "b.png", "frame": {"x":10,"y":0,"w":4,"h":4}, "rotated": true}
+		]}`,
+	} {
+		frames, err := ReadTexturePackerJSON(strings.NewReader(file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(frames, want) {
+			t.Errorf("want %+v but have %+v", want, frames)
+		}
+	}
+}
+
+func TestReadSparrowXML(t *testing.T) {
+	frames, err := ReadSparrowXML(strings.NewReader(`<?xml version="1.0"?>
+<TextureAtlas imagePath="sheet.png">
+	<SubTexture name="b" x="10" y="0" width="4" height="4" rotated="true"/>
+	<SubTexture name="a" x="0" y="0" width="10" height="8"
+		frameX="-1" frameY="-2" frameWidth="12" frameHeight="10"/>
+</TextureAtlas>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Frame{
+		{Name: "b", Rect: Rect{10, 0, 4, 4}, Rotated: true, Source: Size{4, 4}},
+		{Name: "a", Rect: Rect{0, 0, 10, 8}, Source: Size{12, 10}, OffsetX: 1, OffsetY: 2},
+	}
+	if !reflect.DeepEqual(frames, want) {
+		t.Errorf("want %+v but have %+v", want, frames)
+	}
+}
@@ -0,0 +1,220 @@
+package binpacker
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// NewLogged works like New but appends every operation that changes the
+// packer to log, one line per operation. The log is a crash-safe alternative
+// to saving the whole state after each Insert, use Recover to rebuild the
+// packer from it. The log starts with a line holding its format version.
+func NewLogged(width, height int, log io.Writer) (*Packer, error) {
+	if _, err := fmt.Fprintf(log, "version %d\nnew %d %d\n", logVersion, width, height); err != nil {
+		return nil, err
+	}
+	p := New(width, height)
+	p.log = log
+	return p, nil
+}
+
+// logVersion is the version of the log format written by NewLogged. Logs
+// without a version line were written before versioning and are version 0.
+// Increase the version whenever the meaning of a logged operation changes and
+// add a migration from the previous version.
+const logVersion = 3
+
+// migrations[v] converts an operation of log version v to version v+1.
+var migrations = []func(op string, args []int) (string, []int){
+	// version 0 has the same operations as version 1
+	func(op string, args []int) (string, []int) { return op, args },
+	// version 2 adds the tag operation, all others stay the same
+	func(op string, args []int) (string, []int) { return op, args },
+	// version 3 adds the spacing and tagged operations
+	func(op string, args []int) (string, []int) { return op, args },
+}
+
+// Recover replays a log written by a packer created with NewLogged and returns
+// the packer in its last logged state. Logs written by older versions of this
+// package are migrated to the current format. A final line that was only
+// partially written, e.g. due to a crash, is ignored. If log is not nil, the
+// recovered packer appends its following operations to it.
+//
+// Logs compressed with gzip are detected and decompressed. To write one, pass
+// a gzip.Writer to NewLogged and Flush it after each operation that must
+// survive a crash.
+func Recover(r io.Reader, log io.Writer) (*Packer, error) {
+	var p *Packer
+	version := 0
+	lines := bufio.NewReader(r)
+	if magic, _ := lines.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		z, err := gzip.NewReader(lines)
+		if err != nil {
+			return nil, fmt.Errorf("recover: %v", err)
+		}
+		defer z.Close()
+		lines = bufio.NewReader(z)
+	}
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := lines.ReadString('\n')
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break // ignore an incomplete last line
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		op, args, texts, err := parseLogLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("recover: line %d: %v", lineNumber, err)
+		}
+		if len(texts) > 0 && version < 2 {
+			return nil, fmt.Errorf("recover: line %d: version %d has no string arguments", lineNumber, version)
+		}
+
+		if lineNumber == 1 && op == "version" && len(args) == 1 {
+			if args[0] < 0 || args[0] > logVersion {
+				return nil, fmt.Errorf("recover: unsupported log version %d", args[0])
+			}
+			version = args[0]
+			continue
+		}
+		for v := version; v < logVersion; v++ {
+			op, args = migrations[v](op, args)
+		}
+
+		if p == nil {
+			if op != "new" || len(args) != 2 {
+				return nil, fmt.Errorf("recover: line %d: log must start with new", lineNumber)
+			}
+			p = New(args[0], args[1])
+			continue
+		}
+
+		if err := p.replay(op, args, texts); err != nil {
+			return nil, fmt.Errorf("recover: line %d: %v", lineNumber, err)
+		}
+	}
+	if p == nil {
+		return nil, errors.New("recover: empty log")
+	}
+	p.log = log
+	return p, nil
+}
+
+// parseLogLine splits a logged operation into its name, its integer arguments
+// and the quoted strings that follow them.
+func parseLogLine(line string) (op string, args []int, texts []string, err error) {
+	line = strings.TrimSpace(line)
+	quoted := ""
+	if i := strings.IndexByte(line, '"'); i != -1 {
+		line, quoted = line[:i], line[i:]
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil, nil, errors.New("empty line")
+	}
+	op, args = fields[0], make([]int, len(fields)-1)
+	for i, f := range fields[1:] {
+		if args[i], err = strconv.Atoi(f); err != nil {
+			return "", nil, nil, err
+		}
+	}
+	r := strings.NewReader(quoted)
+	for r.Len() > 0 {
+		var s string
+		if _, err := fmt.Fscanf(r, "%q", &s); err != nil {
+			return "", nil, nil, err
+		}
+		texts = append(texts, s)
+	}
+	return op, args, texts, nil
+}
+
+// replay applies a logged operation to p. texts are the operation's string
+// arguments.
+func (p *Packer) replay(op string, args []int, texts []string) error {
+	switch {
+	case op == "tag" && len(args) == 1 && len(texts) == 1:
+		if args[0] < 0 || args[0] >= len(p.items) {
+			return errors.New("tag: invalid index")
+		}
+		p.items[args[0]].tags = append(p.items[args[0]].tags, texts[0])
+	case op == "tagged" && len(args) == 2:
+		p.InsertTagged(args[0], args[1], texts...) // failed inserts fail again
+	case op == "spacing" && len(args) == 1 && len(texts) == 2:
+		return p.SetSpacing(texts[0], texts[1], args[0])
+	case len(texts) > 0:
+		return fmt.Errorf("invalid operation %q with string arguments", op)
+	case op == "insert" && len(args) == 2:
+		p.Insert(args[0], args[1]) // failed inserts are logged as well and fail again
+	case op == "insert" && len(args) == 3:
+		p.InsertPadded(args[0], args[1], args[2])
+	case op == "edge" && len(args) == 3:
+		p.InsertAtEdge(args[0], args[1], Edge(args[2]))
+	case op == "corner" && len(args) == 3:
+		p.InsertNearCorner(args[0], args[1], Edge(args[2]))
+	case op == "commit" && len(args) == 4:
+		return p.Commit(Rect{X: args[0], Y: args[1], Width: args[2], Height: args[3]})
+	case op == "resize" && len(args) == 3:
+		_, _, err := p.resize(args[0], args[1], args[2])
+		if err == ErrNoMoreSpace || err == ErrMaxOccupancy {
+			return nil // failed resizes are logged as well and fail again
+		}
+		return err
+	case op == "enlarge" && len(args) == 2:
+		return p.Enlarge(args[0], args[1])
+	case op == "shrink" && len(args) == 2:
+		_, err := p.ShrinkTo(args[0], args[1])
+		return err
+	case op == "padding" && len(args) == 1:
+		return p.SetPadding(args[0])
+	case op == "maxoccupancy" && len(args) == 1:
+		return p.SetMaxOccupancy(args[0])
+	case op == "align" && len(args) == 1:
+		return p.SetAlignment(args[0])
+	case op == "trim" && len(args) == 4:
+		return p.Trim(args[0], args[1], args[2], args[3])
+	case op == "defect" && len(args) == 4:
+		return p.AddDefects(Rect{X: args[0], Y: args[1], Width: args[2], Height: args[3]})
+	case op == "yup" && len(args) == 1 && (args[0] == 0 || args[0] == 1):
+		return p.SetYUp(args[0] == 1)
+	case op == "origin" && len(args) == 2:
+		return p.SetOrigin(args[0], args[1])
+	default:
+		return fmt.Errorf("invalid operation %q with %d arguments", op, len(args))
+	}
+	return nil
+}
+
+func (p *Packer) writeLog(op string, args ...int) error {
+	return p.writeLogText(op, args)
+}
+
+// writeLogText works like writeLog but appends quoted strings to the integer
+// arguments, so they may contain any characters.
+func (p *Packer) writeLogText(op string, args []int, texts ...string) error {
+	line := op
+	for _, a := range args {
+		line += " " + strconv.Itoa(a)
+	}
+	for _, t := range texts {
+		line += " " + strconv.Quote(t)
+	}
+	return p.writeLogLine(line)
+}
+
+func (p *Packer) writeLogLine(line string) error {
+	if p.log == nil {
+		return nil
+	}
+	if _, err := io.WriteString(p.log, line+"\n"); err != nil {
+		return fmt.Errorf("log: %v", err)
+	}
+	return nil
+}
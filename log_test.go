@@ -0,0 +1,138 @@
+package binpacker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+)
+
+func TestRecoverRebuildsLoggedPacker(t *testing.T) {
+	var log bytes.Buffer
+	p, err := NewLogged(10, 10, &log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Insert(10, 10)
+	p.Insert(5, 5) // fails
+	p.Enlarge(20, 10)
+	p.Insert(5, 5)
+
+	// simulate a crash in the middle of writing the next operation
+	log.WriteString("insert 3")
+
+	var more bytes.Buffer
+	q, err := Recover(bytes.NewReader(log.Bytes()), &more)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Stats() != p.Stats() {
+		t.Errorf("want %+v but have %+v", p.Stats(), q.Stats())
+	}
+	want, _ := p.Insert(1, 1)
+	have, _ := q.Insert(1, 1)
+	if have != want {
+		t.Errorf("recovered packer places at %v, original at %v", have, want)
+	}
+	if more.String() != "insert 1 1\n" {
+		t.Errorf("recovered packer logged %q", more.String())
+	}
+}
+
+func TestRecoverRejectsInvalidLogs(t *testing.T) {
+	for _, log := range []string{
+		"",
+		"insert 1 1\n",
+		"new 10 10\nrotate 5 5\n",
+		"new 10 10\nenlarge 5 5\n",
+		"new ten 10\n",
+		"version 99\nnew 10 10\n",
+		"new 10 10\nversion 1\n",
+		"version 3\nnew 10 10\ninsert 1 1 \"x\"\n",
+		"version 3\nnew 10 10\nspacing 1 \"a\" \"b\n",
+	} {
+		if _, err := Recover(bytes.NewBufferString(log), nil); err == nil {
+			t.Errorf("%q: want error", log)
+		}
+	}
+}
+
+func TestLogIsDeterministic(t *testing.T) {
+	write := func() string {
+		var log bytes.Buffer
+		p, _ := NewLogged(32, 32, &log)
+		p.SetPadding(1)
+		for i := 1; i <= 8; i++ {
+			p.InsertGrow(i*3, 11-i)
+		}
+		p.ShrinkTo(64, 64)
+		return log.String()
+	}
+	first := write()
+	for i := 0; i < 10; i++ {
+		if write() != first {
+			t.Fatal("log differs between runs")
+		}
+	}
+}
+
+func TestRecoverReadsUnversionedLogs(t *testing.T) {
+	p, err := Recover(bytes.NewBufferString("new 10 10\ninsert 5 5\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Occupancy() != 0.25 {
+		t.Errorf("occupancy is %v", p.Occupancy())
+	}
+}
+
+func TestRecoverReadsGzipLogs(t *testing.T) {
+	var log bytes.Buffer
+	z := gzip.NewWriter(&log)
+	p, _ := NewLogged(10, 10, z)
+	p.Insert(5, 5)
+	z.Flush()
+	p.Insert(5, 5)
+	z.Flush()
+	// the gzip stream is never closed, as after a crash
+
+	q, err := Recover(bytes.NewReader(log.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Stats() != p.Stats() {
+		t.Errorf("want %+v but have %+v", p.Stats(), q.Stats())
+	}
+}
+
+func TestRecoverRestoresTags(t *testing.T) {
+	var log bytes.Buffer
+	p, _ := NewLogged(10, 10, &log)
+	r, _ := p.Insert(5, 5)
+	p.Tag(r, "glyph a", `say "hi"`)
+
+	q, err := Recover(bytes.NewReader(log.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"glyph a", `say "hi"`} {
+		if have := q.ItemsWithTag(tag); len(have) != 1 || have[0] != r {
+			t.Errorf("%q: want %v but have %v", tag, r, have)
+		}
+	}
+	if _, err := q.Replace("glyph a", 4, 4); err != nil {
+		t.Errorf("replace by key failed after recover: %v", err)
+	}
+}
+
+func TestRecoverReadsVersion1Logs(t *testing.T) {
+	p, err := Recover(bytes.NewBufferString("version 1\nnew 10 10\ninsert 5 5\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Occupancy() != 0.25 {
+		t.Errorf("occupancy is %v", p.Occupancy())
+	}
+	if _, err := Recover(bytes.NewBufferString("version 1\nnew 10 10\ninsert 5 5\ntag 0 \"a\"\n"), nil); err == nil {
+		t.Error("version 1 logs have no tags but tag was accepted")
+	}
+}
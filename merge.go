@@ -0,0 +1,49 @@
+package binpacker
+
+// Merge repacks all rectangles of a and b into a new bin of the given size,
+// e.g. to consolidate two half-empty atlases. The new packer takes over a's
+// padding, alignment, UV inset and Y-up setting, the rectangles keep their
+// padding and tags. Merge returns where the rectangles of a and of b were
+// moved to. If they do not all fit, ErrNoMoreSpace is returned. a and b are
+// not changed.
+func Merge(a, b *Packer, width, height int) (p *Packer, fromA, fromB map[Rect]Rect, err error) {
+	p = New(width, height)
+	p.padding = a.padding
+	p.alignment = a.alignment
+	p.uvInset = a.uvInset
+	p.yUp = a.yUp
+
+	old := make([]item, 0, len(a.items)+len(b.items))
+	for _, src := range []*Packer{a, b} {
+		for _, it := range src.items {
+			// Items store positions relative to their packer's tree, which
+			// starts at the packer's origin.
+			it.X -= src.originX
+			it.Y -= src.originY
+			it.region.X -= src.originX
+			it.region.Y -= src.originY
+			it.tags = append([]string(nil), it.tags...)
+			old = append(old, it)
+		}
+	}
+	items, ok := repack(&p.root, old, nil)
+	if !ok {
+		return nil, nil, nil, ErrNoMoreSpace
+	}
+
+	p.items = items
+	p.inserts = len(items)
+	p.rects = make([]Rect, len(items))
+	fromA = make(map[Rect]Rect, len(a.items))
+	fromB = make(map[Rect]Rect, len(b.items))
+	for i, it := range items {
+		p.rects[i] = it.Rect
+		if i < len(a.items) {
+			fromA[a.flip(a.items[i].Rect)] = p.flip(it.Rect)
+		} else {
+			fromB[b.flip(b.items[i-len(a.items)].Rect)] = p.flip(it.Rect)
+		}
+	}
+	p.publish()
+	return p, fromA, fromB, nil
+}
@@ -0,0 +1,34 @@
+package binpacker
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	a := New(10, 10)
+	a.SetPadding(1)
+	ra, _ := a.Insert(8, 3)
+	a.Tag(ra, "a")
+	b := New(10, 10)
+	b.SetOrigin(100, 100)
+	rb, _ := b.Insert(10, 6)
+
+	p, fromA, fromB, err := Merge(a, b, 10, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromB[rb] != (Rect{0, 0, 10, 6}) {
+		t.Errorf("b's rect moved to %v", fromB[rb])
+	}
+	if fromA[ra] != (Rect{1, 7, 8, 3}) {
+		t.Errorf("a's rect moved to %v", fromA[ra])
+	}
+	if tagged := p.ItemsWithTag("a"); len(tagged) != 1 || tagged[0] != fromA[ra] {
+		t.Errorf("tags were not kept: %v", tagged)
+	}
+	if len(p.Snapshot().Rects) != 2 || a.Occupancy() == 0 || b.Occupancy() == 0 {
+		t.Error("sources changed or merged rects missing")
+	}
+
+	if _, _, _, err := Merge(a, b, 10, 10); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace, got %v", err)
+	}
+}
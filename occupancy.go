@@ -0,0 +1,32 @@
+package binpacker
+
+import "errors"
+
+// ErrMaxOccupancy is returned when inserting a rectangle would fill the bin
+// beyond the limit set with SetMaxOccupancy.
+var ErrMaxOccupancy = errors.New("insert: bin would exceed its maximum occupancy")
+
+// SetMaxOccupancy makes inserts fail with ErrMaxOccupancy if they would raise
+// the bin's Occupancy above the given percentage, e.g. to keep headroom in a
+// live atlas for urgent inserts. The limit applies to all kinds of inserts,
+// InsertGrow does not enlarge the bin for it. A percentage <= 0 removes the
+// limit, which is the default.
+func (p *Packer) SetMaxOccupancy(percent int) error {
+	if err := p.writeLog("maxoccupancy", percent); err != nil {
+		return err
+	}
+	p.maxOccupancy = percent
+	return nil
+}
+
+// exceedsMaxOccupancy tells whether using another area of the given size would
+// exceed the maximum occupancy.
+func (p *Packer) exceedsMaxOccupancy(area int) bool {
+	return p.exceedsMaxOccupancyOf(usedArea(&p.root)+area, p.binWidth*p.binHeight)
+}
+
+// exceedsMaxOccupancyOf tells whether a bin of the given area with the given
+// used area would exceed the maximum occupancy.
+func (p *Packer) exceedsMaxOccupancyOf(used, binArea int) bool {
+	return p.maxOccupancy > 0 && used*100 > p.maxOccupancy*binArea
+}
@@ -0,0 +1,49 @@
+package binpacker
+
+import "testing"
+
+func TestMaxOccupancy(t *testing.T) {
+	p := New(10, 10)
+	p.SetMaxOccupancy(85)
+	if _, err := p.Insert(10, 8); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Insert(5, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Insert(5, 1); err != ErrMaxOccupancy {
+		t.Errorf("want ErrMaxOccupancy but have %v", err)
+	}
+	if p.Stats().Failures != 1 {
+		t.Error("rejected insert was not counted as a failure")
+	}
+	p.SetMaxOccupancy(0)
+	if _, err := p.Insert(5, 1); err != nil {
+		t.Errorf("limit was not removed: %v", err)
+	}
+}
+
+func TestMaxOccupancyInDryRuns(t *testing.T) {
+	p := New(10, 10)
+	p.Insert(10, 5)
+	p.SetMaxOccupancy(50)
+
+	if p.CanFitAll([]Size{{1, 1}}) {
+		t.Error("CanFitAll ignores the maximum occupancy")
+	}
+	if _, err := p.Preview(1, 1); err != ErrMaxOccupancy {
+		t.Errorf("Preview: want ErrMaxOccupancy but have %v", err)
+	}
+	if err := p.Commit(Rect{0, 5, 1, 1}); err != ErrMaxOccupancy {
+		t.Errorf("Commit: want ErrMaxOccupancy but have %v", err)
+	}
+
+	w, h, ok := p.SuggestEnlarge(10, 5)
+	if !ok || w != 10 || h != 30 {
+		t.Fatalf("SuggestEnlarge gave %dx%d %v, want 10x30", w, h, ok)
+	}
+	p.Enlarge(w, h)
+	if _, err := p.Insert(10, 5); err != nil {
+		t.Errorf("rect does not fit after suggested enlargement: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package binpacker
+
+// ChooseOrientation packs the items in the given order into bins of size
+// binWidth x binHeight and, separately, into bins of size binHeight x
+// binWidth, starting a new bin whenever an item does not fit into any of the
+// previous ones. It returns the bin size that needs fewer bins and the number
+// of bins it needs. On a tie the given orientation is kept. ErrNoMoreSpace is
+// returned if some item does not fit into an empty bin of either orientation.
+func ChooseOrientation(binWidth, binHeight int, items []Size) (width, height, bins int, err error) {
+	bins, ok := countBins(binWidth, binHeight, items)
+	rotated, rotatedOK := countBins(binHeight, binWidth, items)
+	if rotatedOK && (!ok || rotated < bins) {
+		return binHeight, binWidth, rotated, nil
+	}
+	if !ok {
+		return 0, 0, 0, ErrNoMoreSpace
+	}
+	return binWidth, binHeight, bins, nil
+}
+
+func countBins(width, height int, items []Size) (int, bool) {
+	var bins []*Packer
+	for _, it := range items {
+		placed := false
+		for _, b := range bins {
+			if _, err := b.Insert(it.Width, it.Height); err == nil {
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			b := New(width, height)
+			if _, err := b.Insert(it.Width, it.Height); err != nil {
+				return 0, false
+			}
+			bins = append(bins, b)
+		}
+	}
+	return len(bins), true
+}
@@ -0,0 +1,23 @@
+package binpacker
+
+import "testing"
+
+func TestChooseOrientation(t *testing.T) {
+	items := []Size{{10, 4}, {10, 4}, {10, 2}}
+	w, h, bins, err := ChooseOrientation(5, 10, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w != 10 || h != 5 || bins != 3 {
+		t.Errorf("got %dx%d with %d bins, want 10x5 with 3 bins", w, h, bins)
+	}
+
+	w, h, bins, _ = ChooseOrientation(10, 10, items)
+	if w != 10 || h != 10 || bins != 1 {
+		t.Errorf("got %dx%d with %d bins, want 10x10 with 1 bin", w, h, bins)
+	}
+
+	if _, _, _, err := ChooseOrientation(5, 10, []Size{{11, 1}}); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace, got %v", err)
+	}
+}
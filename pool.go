@@ -0,0 +1,141 @@
+package binpacker
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Pool is a set of independent bins of equal size that can be used from many
+// goroutines at once. Every bin has its own lock so concurrent inserts rarely
+// wait for each other. Each Insert goes to the emptiest bin first and moves on
+// to fuller ones if it does not fit.
+type Pool struct {
+	shards []shard
+}
+
+type shard struct {
+	mu     sync.Mutex
+	packer *Packer
+	used   int64 // area of the inserted rectangles, accessed atomically
+}
+
+// NewPool creates a Pool of the given number of bins.
+func NewPool(bins, width, height int) *Pool {
+	shards := make([]shard, bins)
+	for i := range shards {
+		shards[i].packer = New(width, height)
+	}
+	return &Pool{shards: shards}
+}
+
+// Insert places a rectangle in one of the bins and returns that bin's index
+// along with the position. ErrNoMoreSpace is returned if no bin has room.
+func (p *Pool) Insert(width, height int) (bin int, r Rect, err error) {
+	order := make([]int, len(p.shards))
+	used := make([]int64, len(p.shards))
+	for i := range order {
+		order[i] = i
+		used[i] = atomic.LoadInt64(&p.shards[i].used)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return used[order[i]] < used[order[j]]
+	})
+
+	for _, i := range order {
+		s := &p.shards[i]
+		s.mu.Lock()
+		r, err := s.packer.Insert(width, height)
+		s.mu.Unlock()
+		if err == nil {
+			atomic.AddInt64(&s.used, int64(width*height))
+			return i, r, nil
+		}
+	}
+	return 0, Rect{}, ErrNoMoreSpace
+}
+
+// Occupancy returns the occupancy of the given bin, see Packer.Occupancy.
+func (p *Pool) Occupancy(bin int) float64 {
+	s := &p.shards[bin]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.packer.Occupancy()
+}
+
+// Placement is the position of a rectangle in one of a Pool's bins.
+type Placement struct {
+	Bin  int
+	Rect Rect
+}
+
+// Rebalance repacks the rectangles of all bins into as few bins as possible,
+// filling the first bins and leaving the last ones empty. It returns where
+// each rectangle was moved to. If the rectangles cannot be repacked,
+// ErrNoMoreSpace is returned and the bins are unchanged.
+func (p *Pool) Rebalance() (map[Placement]Placement, error) {
+	for i := range p.shards {
+		p.shards[i].mu.Lock()
+		defer p.shards[i].mu.Unlock()
+	}
+
+	type entry struct {
+		from Placement
+		item item
+	}
+	var all []entry
+	for i := range p.shards {
+		for _, it := range p.shards[i].packer.items {
+			all = append(all, entry{from: Placement{i, it.Rect}, item: it})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return packsBefore(all[i].item.region, all[j].item.region)
+	})
+
+	roots := make([]node, len(p.shards))
+	items := make([][]item, len(p.shards))
+	for i := range roots {
+		b := p.shards[i].packer
+		roots[i] = node{Rect: b.trimmed(b.binWidth, b.binHeight)}
+	}
+	moved := make(map[Placement]Placement, len(all))
+	for _, e := range all {
+		placed := false
+		for bin := range roots {
+			r := e.item.region
+			n, right, bottom := findFree(&roots[bin], r.Width, r.Height, nil)
+			if n == nil {
+				continue
+			}
+			split(n, r.Width, r.Height, right, bottom)
+			it := e.item
+			it.region = n.Rect
+			it.X = n.X + e.item.X - r.X
+			it.Y = n.Y + e.item.Y - r.Y
+			items[bin] = append(items[bin], it)
+			moved[e.from] = Placement{bin, it.Rect}
+			placed = true
+			break
+		}
+		if !placed {
+			return nil, ErrNoMoreSpace
+		}
+	}
+
+	for i := range p.shards {
+		s := &p.shards[i]
+		s.packer.root = roots[i]
+		s.packer.items = items[i]
+		s.packer.inserts = len(items[i])
+		s.packer.rects = make([]Rect, len(items[i]))
+		used := 0
+		for j, it := range items[i] {
+			s.packer.rects[j] = it.Rect
+			used += it.Width * it.Height
+		}
+		s.packer.publish()
+		atomic.StoreInt64(&s.used, int64(used))
+	}
+	return moved, nil
+}
@@ -0,0 +1,56 @@
+package binpacker
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPoolSpreadsInsertsOverBins(t *testing.T) {
+	pool := NewPool(4, 100, 100)
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := pool.Insert(50, 20); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 40 rects of 1000 pixels fill all 4 bins exactly
+	for bin := 0; bin < 4; bin++ {
+		if o := pool.Occupancy(bin); o != 1 {
+			t.Errorf("bin %d has occupancy %v", bin, o)
+		}
+	}
+	if _, _, err := pool.Insert(1, 1); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace but have %v", err)
+	}
+}
+
+func TestPoolRebalance(t *testing.T) {
+	pool := NewPool(3, 10, 10)
+	for i := 0; i < 3; i++ {
+		pool.Insert(10, 4) // one per bin
+	}
+	pool.Insert(10, 2)
+
+	moved, err := pool.Rebalance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moved) != 4 {
+		t.Errorf("%d rects moved, want 4", len(moved))
+	}
+	if pool.Occupancy(0) != 1 || pool.Occupancy(1) != 0.4 || pool.Occupancy(2) != 0 {
+		t.Errorf("occupancies are %v, %v, %v",
+			pool.Occupancy(0), pool.Occupancy(1), pool.Occupancy(2))
+	}
+	for _, to := range moved {
+		if to.Bin == 2 {
+			t.Errorf("rect moved to the last bin: %v", to)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package binpacker
+
+import "errors"
+
+// Preview returns where Insert would place a rectangle of the given size,
+// without changing the packer. Call Commit with the result to actually
+// insert it, e.g. after drawing directly into that region. Like Insert,
+// Preview returns ErrMaxOccupancy if the rectangle would exceed the limit set
+// with SetMaxOccupancy.
+func (p *Packer) Preview(width, height int) (Rect, error) {
+	regionW, regionH, padding := p.regionSize(width, height, p.padding)
+	if p.exceedsMaxOccupancy(regionW * regionH) {
+		return Rect{}, ErrMaxOccupancy
+	}
+	leaf, right, bottom := findFree(&p.root, regionW, regionH, p.defects)
+	if leaf == nil {
+		return Rect{}, ErrNoMoreSpace
+	}
+	r := Rect{X: leaf.X + padding, Y: leaf.Y + padding, Width: width, Height: height}
+	if right {
+		r.X += leaf.Width - regionW
+	}
+	if bottom {
+		r.Y += leaf.Height - regionH
+	}
+	return p.flip(r), nil
+}
+
+// Commit inserts a rectangle at a position returned by Preview. It fails if
+// the packer was changed in a way that makes the position unavailable, or
+// with ErrMaxOccupancy if the rectangle would exceed the maximum occupancy.
+func (p *Packer) Commit(r Rect) error {
+	if err := p.writeLog("commit", r.X, r.Y, r.Width, r.Height); err != nil {
+		return err
+	}
+	if _, err := p.insertAt(r); err == ErrMaxOccupancy {
+		return err
+	} else if err != nil {
+		return errors.New("commit: rect is not free")
+	}
+	return nil
+}
+
+// insertAt inserts r at its position. This is only possible if r with its
+// padding lies in a corner of a free leaf.
+func (p *Packer) insertAt(r Rect) (Rect, error) {
+	want := p.flip(r)
+	_, _, padding := p.regionSize(r.Width, r.Height, p.padding)
+	return p.insert(r.Width, r.Height, p.padding, p.placeAt(want.X-padding, want.Y-padding))
+}
+
+// placeAt returns a placer that puts the region at (x, y). It finds a free
+// leaf that has the region in one of its corners.
+func (p *Packer) placeAt(x, y int) placer {
+	return func(w, h int) (*node, bool, bool) {
+		var found *node
+		right, bottom := false, false
+		leaves(&p.root, func(n *node) bool {
+			if w > n.Width || h > n.Height ||
+				hitsDefect(Rect{X: x, Y: y, Width: w, Height: h}, p.defects) {
+				return true
+			}
+			left, top := x == n.X, y == n.Y
+			right, bottom = x+w == n.X+n.Width, y+h == n.Y+n.Height
+			if (left || right) && (top || bottom) {
+				found = n
+				right, bottom = !left, !top
+				return false
+			}
+			return true
+		})
+		return found, right, bottom
+	}
+}
@@ -0,0 +1,34 @@
+package binpacker
+
+import "testing"
+
+func TestPreviewAndCommit(t *testing.T) {
+	p := New(100, 100)
+	p.SetPadding(1)
+	p.Insert(20, 20)
+
+	r, err := p.Preview(10, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Overlaps(r) {
+		t.Fatal("Preview changed the packer")
+	}
+	if err := p.Commit(r); err != nil {
+		t.Fatal(err)
+	}
+	if found, ok := p.At(r.X, r.Y); !ok || found != r {
+		t.Errorf("committed rect %v was not inserted", r)
+	}
+
+	q := New(100, 100)
+	q.SetPadding(1)
+	q.Insert(20, 20)
+	if want, _ := q.Insert(10, 10); want != r {
+		t.Errorf("Preview gave %v but Insert places at %v", r, want)
+	}
+
+	if err := p.Commit(r); err == nil {
+		t.Error("committing twice should fail")
+	}
+}
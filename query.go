@@ -0,0 +1,64 @@
+package binpacker
+
+// ItemsIn returns all inserted rectangles that intersect r, in insertion
+// order.
+func (p *Packer) ItemsIn(r Rect) []Rect {
+	r = p.flip(r)
+	var rects []Rect
+	for _, it := range p.items {
+		if it.Intersects(r) {
+			rects = append(rects, p.flip(it.Rect))
+		}
+	}
+	return rects
+}
+
+// At returns the inserted rectangle that covers the pixel (x, y), if any.
+func (p *Packer) At(x, y int) (Rect, bool) {
+	pixel := p.flip(Rect{X: x, Y: y, Width: 1, Height: 1})
+	for _, it := range p.items {
+		if it.Intersects(pixel) {
+			return p.flip(it.Rect), true
+		}
+	}
+	return Rect{}, false
+}
+
+// Overlaps tells whether r cannot be placed in the bin as it is, e.g. to
+// validate a hand-placed region. This is the case if r intersects any used
+// region, a defect or a trimmed border, or if it sticks out of the bin. Used
+// regions include the inserted rectangles with their padding and, after
+// Enlarge, the whole previous bin area. Use ItemsIn to get the conflicting
+// rectangles.
+func (p *Packer) Overlaps(r Rect) bool {
+	if r.Width <= 0 || r.Height <= 0 {
+		return false
+	}
+	bin, inner := p.trimmed(p.binWidth, p.binHeight), p.flip(r)
+	if inner.X < bin.X || inner.Y < bin.Y ||
+		inner.X+inner.Width > bin.X+bin.Width ||
+		inner.Y+inner.Height > bin.Y+bin.Height ||
+		hitsDefect(inner, p.defects) {
+		return true
+	}
+	overlaps := false
+	p.Walk(func(region Rect, used bool, _ int) bool {
+		overlaps = used && region.Intersects(r)
+		return !overlaps
+	})
+	return overlaps
+}
+
+// Collisions checks a list of rectangles, e.g. an imported layout, for
+// overlaps. It returns the index pairs of all intersecting rectangles.
+func Collisions(rects []Rect) [][2]int {
+	var pairs [][2]int
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			if rects[i].Intersects(rects[j]) {
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,82 @@
+package binpacker
+
+import "testing"
+
+func TestItemsIn(t *testing.T) {
+	p := New(100, 100)
+	a, _ := p.Insert(50, 50)
+	b, _ := p.Insert(50, 50)
+	p.Insert(50, 50)
+
+	found := p.ItemsIn(Rect{X: 40, Y: 45, Width: 5, Height: 10})
+	if len(found) != 2 || found[0] != a || found[1] != b {
+		t.Errorf("want %v and %v but have %v", a, b, found)
+	}
+	if found := p.ItemsIn(Rect{X: 99, Y: 99, Width: 1, Height: 1}); len(found) != 0 {
+		t.Errorf("free corner contains %v", found)
+	}
+}
+
+func TestAt(t *testing.T) {
+	p := New(100, 100)
+	p.SetPadding(1)
+	r, _ := p.Insert(10, 10)
+
+	if found, ok := p.At(r.X+9, r.Y); !ok || found != r {
+		t.Errorf("want %v but have %v, %v", r, found, ok)
+	}
+	if _, ok := p.At(r.X+10, r.Y); ok {
+		t.Error("padding is reported as item")
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	p := New(100, 100)
+	p.SetPadding(2)
+	p.Insert(10, 10) // occupies 0,0 to 14,14
+
+	if !p.Overlaps(Rect{X: 13, Y: 13, Width: 5, Height: 5}) {
+		t.Error("padding should count as overlap")
+	}
+	if p.Overlaps(Rect{X: 14, Y: 0, Width: 5, Height: 5}) {
+		t.Error("free region overlaps")
+	}
+}
+
+func TestOverlapsChecksBinBordersAndDefects(t *testing.T) {
+	p := New(100, 100)
+	p.Trim(5, 0, 0, 0)
+	p.AddDefects(Rect{50, 50, 2, 2})
+
+	for _, r := range []Rect{
+		{95, 0, 10, 10},  // sticks out on the right
+		{20, -1, 10, 10}, // sticks out at the top
+		{0, 20, 10, 10},  // lies in the trimmed border
+		{45, 45, 10, 10}, // covers the defect
+	} {
+		if !p.Overlaps(r) {
+			t.Errorf("%v should conflict", r)
+		}
+	}
+	if p.Overlaps(Rect{5, 0, 10, 10}) {
+		t.Error("free region next to the trim overlaps")
+	}
+
+	p = New(100, 100)
+	p.SetYUp(true)
+	p.AddDefects(Rect{0, 0, 10, 10})
+	if !p.Overlaps(Rect{0, 0, 5, 5}) || p.Overlaps(Rect{0, 90, 10, 10}) {
+		t.Error("defects are not checked in Y-up coordinates")
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	pairs := Collisions([]Rect{
+		{0, 0, 10, 10},
+		{10, 0, 10, 10},
+		{5, 5, 10, 10},
+	})
+	if len(pairs) != 2 || pairs[0] != [2]int{0, 2} || pairs[1] != [2]int{1, 2} {
+		t.Errorf("unexpected collisions %v", pairs)
+	}
+}
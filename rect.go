@@ -0,0 +1,79 @@
+package binpacker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalText encodes r in the compact form "x,y,wxh", e.g. "10,20,30x40". It
+// lets Rects be used as JSON map keys, JSON values are objects, see
+// MarshalJSON.
+func (r Rect) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d,%d,%dx%d", r.X, r.Y, r.Width, r.Height)), nil
+}
+
+// UnmarshalText parses the form "x,y,wxh" written by MarshalText. The width
+// and height must not be negative.
+func (r *Rect) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), ",")
+	if len(parts) != 3 {
+		return fmt.Errorf("rect: %q is not of the form x,y,wxh", text)
+	}
+	size := strings.Split(parts[2], "x")
+	if len(size) != 2 {
+		return fmt.Errorf("rect: %q is not of the form x,y,wxh", text)
+	}
+	var values [4]int
+	for i, s := range []string{parts[0], parts[1], size[0], size[1]} {
+		v, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("rect: %q is not of the form x,y,wxh", text)
+		}
+		values[i] = v
+	}
+	if values[2] < 0 || values[3] < 0 {
+		return fmt.Errorf("rect: %q has a negative size", text)
+	}
+	*r = Rect{X: values[0], Y: values[1], Width: values[2], Height: values[3]}
+	return nil
+}
+
+// jsonRect has the same fields as Rect but none of its methods.
+type jsonRect struct{ X, Y, Width, Height int }
+
+// MarshalJSON encodes r as an object with the fields X, Y, Width and Height.
+// Without it encoding/json would use MarshalText.
+func (r Rect) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonRect(r))
+}
+
+// UnmarshalJSON decodes an object written by MarshalJSON or a string in the
+// form written by MarshalText.
+func (r *Rect) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return r.UnmarshalText([]byte(text))
+	}
+	var v jsonRect
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*r = Rect(v)
+	return nil
+}
+
+// Intersects tells whether r and s share any area. Rects with a zero width or
+// height never intersect anything.
+func (r Rect) Intersects(s Rect) bool {
+	return r.Width > 0 && r.Height > 0 && s.Width > 0 && s.Height > 0 &&
+		r.X < s.X+s.Width && s.X < r.X+r.Width &&
+		r.Y < s.Y+s.Height && s.Y < r.Y+r.Height
+}
+
+// Size is the size of a rectangle that is yet to be placed.
+type Size struct{ Width, Height int }
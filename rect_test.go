@@ -0,0 +1,75 @@
+package binpacker
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRectTextRoundTrip(t *testing.T) {
+	text, _ := Rect{X: 1, Y: -2, Width: 30, Height: 40}.MarshalText()
+	if string(text) != "1,-2,30x40" {
+		t.Fatalf("unexpected text %q", text)
+	}
+	var r Rect
+	if err := r.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if r != (Rect{X: 1, Y: -2, Width: 30, Height: 40}) {
+		t.Errorf("round trip gave %v", r)
+	}
+}
+
+func TestRectUnmarshalTextRejectsGarbage(t *testing.T) {
+	for _, s := range []string{"", "1,2,3", "1,2,3x", "a,2,3x4", "1,2,3x4x5", "1,2,-3x4", "1,2,3x-4"} {
+		var r Rect
+		if err := r.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("%q: want error", s)
+		}
+	}
+}
+
+func TestRectAsJSONMapKey(t *testing.T) {
+	m := map[Rect]Rect{{0, 0, 1, 2}: {3, 4, 1, 2}}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"0,0,1x2":{"X":3,"Y":4,"Width":1,"Height":2}}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
+func TestRectJSONKeepsFieldNames(t *testing.T) {
+	data, _ := json.Marshal(Rect{1, 2, 3, 4})
+	if string(data) != `{"X":1,"Y":2,"Width":3,"Height":4}` {
+		t.Errorf("unexpected JSON %s", data)
+	}
+	for _, s := range []string{`{"X":1,"Y":2,"Width":3,"Height":4}`, `"1,2,3x4"`} {
+		var r Rect
+		if err := json.Unmarshal([]byte(s), &r); err != nil || r != (Rect{1, 2, 3, 4}) {
+			t.Errorf("%s: got %v, %v", s, r, err)
+		}
+	}
+}
+
+func TestRectIntersects(t *testing.T) {
+	r := Rect{X: 0, Y: 0, Width: 10, Height: 10}
+	tests := []struct {
+		s    Rect
+		want bool
+	}{
+		{Rect{5, 5, 10, 10}, true},
+		{Rect{2, 2, 1, 1}, true},
+		{Rect{10, 0, 5, 5}, false},
+		{Rect{0, 10, 5, 5}, false},
+		{Rect{5, 5, 0, 5}, false},
+	}
+	for _, tt := range tests {
+		if got := r.Intersects(tt.s); got != tt.want {
+			t.Errorf("%v intersects %v: want %v", r, tt.s, tt.want)
+		}
+		if got := tt.s.Intersects(r); got != tt.want {
+			t.Errorf("%v intersects %v: want %v", tt.s, r, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package binpacker
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Report writes a human-readable summary of the packer to w: the bin size,
+// occupancy, all inserted rectangles in insertion order and how much space is
+// wasted. Wasted space is broken down into padding and alignment, trimmed
+// borders, defects in the free space and the previous bin area that Enlarge
+// marked as used. The free area does not include defects.
+func (p *Packer) Report(w io.Writer) error {
+	t := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(t, "bin:\t%dx%d\n", p.binWidth, p.binHeight)
+	fmt.Fprintf(t, "occupancy:\t%.2f%%\n", 100*p.Occupancy())
+	fmt.Fprintf(t, "items:\t%d\n", len(p.items))
+	fmt.Fprintln(t)
+
+	fmt.Fprintln(t, "#\tx\ty\twidth\theight")
+	itemArea, regionArea := 0, 0
+	for i, it := range p.items {
+		r := p.flip(it.Rect)
+		fmt.Fprintf(t, "%d\t%d\t%d\t%d\t%d\n", i, r.X, r.Y, r.Width, r.Height)
+		itemArea += it.Width * it.Height
+		regionArea += it.region.Width * it.region.Height
+	}
+	fmt.Fprintln(t)
+
+	freeArea, fragments, largest := 0, 0, Rect{}
+	p.Walk(func(r Rect, used bool, _ int) bool {
+		if !used && r.Width > 0 && r.Height > 0 {
+			freeArea += r.Width * r.Height
+			fragments++
+			if r.Width*r.Height > largest.Width*largest.Height {
+				largest = r
+			}
+		}
+		return true
+	})
+	// Defects lie in free space, they are assumed not to overlap each other.
+	defectArea := 0
+	leaves(&p.root, func(n *node) bool {
+		for _, d := range p.defects {
+			defectArea += overlapArea(n.Rect, d)
+		}
+		return true
+	})
+	freeArea -= defectArea
+	binArea := p.binWidth * p.binHeight
+	trimmed := p.trimmed(p.binWidth, p.binHeight)
+	trimArea := binArea - trimmed.Width*trimmed.Height
+	fmt.Fprintf(t, "free area:\t%d\t(%s in %d fragments, largest %dx%d)\n",
+		freeArea, percent(freeArea, binArea), fragments, largest.Width, largest.Height)
+	fmt.Fprintf(t, "padding and alignment:\t%d\t(%s)\n",
+		regionArea-itemArea, percent(regionArea-itemArea, binArea))
+	fmt.Fprintf(t, "trimmed borders:\t%d\t(%s)\n", trimArea, percent(trimArea, binArea))
+	fmt.Fprintf(t, "defects:\t%d\t(%s)\n", defectArea, percent(defectArea, binArea))
+	blocked := binArea - freeArea - regionArea - trimArea - defectArea
+	fmt.Fprintf(t, "unusable after Enlarge:\t%d\t(%s)\n", blocked, percent(blocked, binArea))
+
+	return t.Flush()
+}
+
+func percent(part, total int) string {
+	if total == 0 {
+		return "0.00%"
+	}
+	return fmt.Sprintf("%.2f%%", 100*float64(part)/float64(total))
+}
+
+// overlapArea returns the area that r and s share.
+func overlapArea(r, s Rect) int {
+	w := min(r.X+r.Width, s.X+s.Width) - max(r.X, s.X)
+	h := min(r.Y+r.Height, s.Y+s.Height) - max(r.Y, s.Y)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
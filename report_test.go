@@ -0,0 +1,57 @@
+package binpacker
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	p := New(20, 10)
+	p.SetPadding(1)
+	p.Insert(8, 8)
+
+	var buf bytes.Buffer
+	if err := p.Report(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `bin:        20x10
+occupancy:  50.00%
+items:      1
+
+#  x  y  width  height
+0  1  1  8      8
+
+free area:               100  (50.00% in 1 fragments, largest 10x10)
+padding and alignment:   36   (18.00%)
+trimmed borders:         0    (0.00%)
+defects:                 0    (0.00%)
+unusable after Enlarge:  0    (0.00%)
+`
+	if buf.String() != want {
+		t.Errorf("want\n%s\nbut have\n%s", want, buf.String())
+	}
+}
+
+func TestReportSeparatesTrimAndDefects(t *testing.T) {
+	p := New(20, 10)
+	p.Trim(1, 0, 1, 0)
+	p.AddDefects(Rect{10, 4, 2, 2})
+
+	var buf bytes.Buffer
+	p.Report(&buf)
+	want := `bin:        20x10
+occupancy:  0.00%
+items:      0
+
+#  x  y  width  height
+
+free area:               176  (88.00% in 1 fragments, largest 18x10)
+padding and alignment:   0    (0.00%)
+trimmed borders:         20   (10.00%)
+defects:                 4    (2.00%)
+unusable after Enlarge:  0    (0.00%)
+`
+	if buf.String() != want {
+		t.Errorf("want\n%s\nbut have\n%s", want, buf.String())
+	}
+}
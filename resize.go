@@ -0,0 +1,161 @@
+package binpacker
+
+import "errors"
+
+// Resize changes the size of the rectangle tagged with key, see Tag, e.g. to
+// replace a cached glyph with a slightly larger variant. The rectangle's
+// region is freed and the new size is placed at the same position if the
+// freed region and the free space next to it allow, so shrinking frees the
+// slack and growing uses adjacent free space. Only as a last resort is the
+// rectangle moved to a new position, in which case moved is true. The
+// rectangle keeps its tags and its place in the insertion order.
+//
+// Rectangles inserted before the last Enlarge have no region of their own,
+// the whole previous bin counts as used. They are only resized in place if
+// they fit their original region, otherwise they are moved and their old
+// space stays used.
+//
+// Resize is atomic: if the new size does not fit, the error is returned and
+// the packer is left unchanged, and a Snapshot shows either the old or the
+// new rectangle, never neither.
+func (p *Packer) Resize(key string, width, height int) (r Rect, moved bool, err error) {
+	index := -1
+	for i, it := range p.items {
+		for _, t := range it.tags {
+			if t == key {
+				index = i
+			}
+		}
+		if index != -1 {
+			break
+		}
+	}
+	if index == -1 {
+		return Rect{}, false, errors.New("resize: no rect has the key " + key)
+	}
+	if err := p.writeLog("resize", index, width, height); err != nil {
+		return Rect{}, false, err
+	}
+	return p.resize(index, width, height)
+}
+
+// Replace removes the rectangle tagged with key and inserts one of the given
+// size in its place as a single atomic operation, see Resize. If the new size
+// does not fit, the old rectangle is kept and the error is returned. The new
+// rectangle keeps the old one's tags.
+func (p *Packer) Replace(key string, width, height int) (Rect, error) {
+	r, _, err := p.Resize(key, width, height)
+	return r, err
+}
+
+func (p *Packer) resize(index, width, height int) (Rect, bool, error) {
+	if index < 0 || index >= len(p.items) {
+		return Rect{}, false, errors.New("resize: invalid index")
+	}
+	old := p.items[index]
+
+	n := findUsed(&p.root, old.region)
+	if n == nil {
+		// The region was merged into the used area by Enlarge.
+		regionW, regionH, _, _ := p.unpaddedRegionSize(width, height, old.padding, old.unpadded)
+		if regionW <= old.region.Width && regionH <= old.region.Height {
+			it := old
+			it.Width, it.Height = width, height
+			p.replaceItem(index, it)
+			return p.flip(it.Rect), false, nil
+		}
+	}
+
+	backup := copyTree(&p.root)
+	if n != nil {
+		free(n)
+	}
+	_, err := p.insertUnpadded(width, height, old.padding, old.unpadded, p.placeAt(old.region.X, old.region.Y))
+	if err == ErrNoMoreSpace {
+		// Away from its old place the rectangle no longer touches the edges
+		// that it was inserted at, so it gets the full padding.
+		// the fallback below counts the failure if it fails, too
+		p.failures--
+		p.failStreak--
+		_, err = p.insert(width, height, old.padding, nil)
+	}
+	if err != nil {
+		p.root = *backup
+		return Rect{}, false, err
+	}
+
+	last := len(p.items) - 1
+	it := p.items[last]
+	it.tags = old.tags
+	p.items = p.items[:last]
+	p.inserts--
+	if n != nil {
+		p.removals++
+	}
+	p.replaceItem(index, it)
+	return p.flip(it.Rect), it.X != old.X || it.Y != old.Y, nil
+}
+
+// replaceItem sets the item at index and publishes the change.
+func (p *Packer) replaceItem(index int, it item) {
+	p.items[index] = it
+	// rects is shared with snapshots and must not be modified.
+	p.rects = make([]Rect, len(p.items))
+	for i := range p.items {
+		p.rects[i] = p.items[i].Rect
+	}
+	p.publish()
+}
+
+// findUsed returns the node that holds the used region or nil if there is
+// none.
+func findUsed(n *node, region Rect) *node {
+	if n.left == nil && n.right == nil {
+		return nil
+	}
+	if n.Rect == region {
+		return n
+	}
+	for _, c := range []*node{n.left, n.right} {
+		if c != nil {
+			if found := findUsed(c, region); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// free turns the used region of n into free space. If nothing is used below
+// n, n becomes a leaf again, merging the region with the free space next to
+// it. Otherwise the tree has to stay a valid guillotine partition: the region
+// and the child beside it form a strip that spans n, like the other child.
+// That strip becomes a new node with the region as a free leaf, and n and the
+// strip get empty used regions in their top-left corners.
+func free(n *node) {
+	if (n.left == nil || usedArea(n.left) == 0) && (n.right == nil || usedArea(n.right) == 0) {
+		n.Rect = extent(n)
+		n.left, n.right = nil, nil
+		return
+	}
+	region := extent(n)
+	beside, rest := n.left, n.right
+	if !formsRect(n.Rect, extent(beside)) {
+		beside, rest = rest, beside
+	}
+	strip := union(n.Rect, extent(beside))
+	n.left = &node{
+		Rect:  Rect{X: strip.X, Y: strip.Y},
+		left:  &node{Rect: n.Rect},
+		right: beside,
+	}
+	n.right = rest
+	n.Rect = Rect{X: region.X, Y: region.Y}
+}
+
+// formsRect tells whether a and b lie side by side and together form a
+// rectangle.
+func formsRect(a, b Rect) bool {
+	u := union(a, b)
+	return u.Width*u.Height == a.Width*a.Height+b.Width*b.Height
+}
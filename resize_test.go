@@ -0,0 +1,134 @@
+package binpacker
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestResize(t *testing.T) {
+	var log bytes.Buffer
+	p, _ := NewLogged(100, 100, &log)
+	glyph, _ := p.Insert(10, 10)
+	p.Tag(glyph, "a")
+	other, _ := p.Insert(5, 5)
+
+	r, moved, err := p.Resize("a", 8, 9)
+	if err != nil || moved || r != (Rect{0, 0, 8, 9}) {
+		t.Errorf("shrinking gave %v, %v, %v", r, moved, err)
+	}
+	r, moved, err = p.Resize("a", 10, 10)
+	if err != nil || moved || r != glyph {
+		t.Errorf("growing within the region gave %v, %v, %v", r, moved, err)
+	}
+	r, moved, err = p.Resize("a", 12, 12)
+	if err != nil || !moved || r.Width != 12 || r.Intersects(glyph) || r.Intersects(other) {
+		t.Errorf("growing beyond the region gave %v, %v, %v", r, moved, err)
+	}
+	if freed, _ := p.Insert(10, 10); freed != glyph {
+		t.Errorf("old region was not freed, insert went to %v", freed)
+	}
+	if s := p.Snapshot(); len(s.Rects) != 3 || s.Rects[0] != r {
+		t.Errorf("snapshot is %v", s.Rects)
+	}
+	if tagged := p.ItemsWithTag("a"); len(tagged) != 1 || tagged[0] != r {
+		t.Errorf("tag was not kept: %v", tagged)
+	}
+	if _, _, err := p.Resize("b", 1, 1); err == nil {
+		t.Error("resizing an unknown key should fail")
+	}
+
+	q, err := Recover(bytes.NewReader(log.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := q.Snapshot(); len(s.Rects) != 3 || s.Rects[0] != r {
+		t.Errorf("recovered snapshot is %v", s.Rects)
+	}
+}
+
+func TestFailedResizeKeepsOldRect(t *testing.T) {
+	p := New(20, 20)
+	r, _ := p.Insert(10, 10)
+	p.Tag(r, "a")
+	before := p.Snapshot()
+
+	if _, _, err := p.Resize("a", 30, 30); err != ErrNoMoreSpace {
+		t.Fatalf("want ErrNoMoreSpace but have %v", err)
+	}
+	if tagged := p.ItemsWithTag("a"); len(tagged) != 1 || tagged[0] != r {
+		t.Errorf("rect changed to %v", tagged)
+	}
+	if after := p.Snapshot(); len(after.Rects) != 1 || after.Rects[0] != before.Rects[0] {
+		t.Errorf("snapshot changed to %v", after.Rects)
+	}
+}
+
+func TestResizeUsesAndFreesAdjacentSpace(t *testing.T) {
+	p := New(20, 10)
+	r, _ := p.Insert(10, 10)
+	p.Tag(r, "a")
+
+	r, moved, err := p.Resize("a", 15, 10)
+	if err != nil || moved || r != (Rect{0, 0, 15, 10}) {
+		t.Errorf("growing into free space gave %v, %v, %v", r, moved, err)
+	}
+	r, moved, err = p.Resize("a", 5, 10)
+	if err != nil || moved || r != (Rect{0, 0, 5, 10}) {
+		t.Errorf("shrinking gave %v, %v, %v", r, moved, err)
+	}
+	if slack, err := p.Insert(15, 10); err != nil || slack != (Rect{5, 0, 15, 10}) {
+		t.Errorf("slack was not freed: %v, %v", slack, err)
+	}
+	if p.Occupancy() != 1 {
+		t.Errorf("occupancy is %v", p.Occupancy())
+	}
+	if _, _, err := p.Resize("a", 6, 10); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace but have %v", err)
+	}
+	if s := p.Stats(); s.Inserts != 2 || s.Failures != 1 {
+		t.Errorf("stats are %+v", s)
+	}
+	if r, _ := p.At(0, 0); r != (Rect{0, 0, 5, 10}) {
+		t.Errorf("failed resize changed the rect to %v", r)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	p := New(20, 10)
+	old, _ := p.Insert(10, 10)
+	p.Tag(old, "glyph")
+	p.Insert(10, 10)
+
+	if _, err := p.Replace("glyph", 11, 10); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace but have %v", err)
+	}
+	if r := p.ItemsWithTag("glyph"); len(r) != 1 || r[0] != old {
+		t.Errorf("failed replace changed the rect to %v", r)
+	}
+	r, err := p.Replace("glyph", 8, 8)
+	if err != nil || r != (Rect{0, 0, 8, 8}) {
+		t.Errorf("replace gave %v, %v", r, err)
+	}
+	if tagged := p.ItemsWithTag("glyph"); len(tagged) != 1 || tagged[0] != r {
+		t.Errorf("key does not find the new rect: %v", tagged)
+	}
+}
+
+func TestResizeKeepsPaddingOfEdgeItems(t *testing.T) {
+	p := New(20, 20)
+	p.SetPadding(2)
+	r, _ := p.InsertAtEdge(4, 4, Left)
+	p.Tag(r, "a")
+	if r != (Rect{0, 2, 4, 4}) {
+		t.Fatalf("inserted at %v", r)
+	}
+
+	r, moved, err := p.Resize("a", 4, 4)
+	if err != nil || moved || r != (Rect{0, 2, 4, 4}) {
+		t.Errorf("resizing to the same size gave %v, %v, %v", r, moved, err)
+	}
+	// both rectangles have a padding of 2
+	if next, _ := p.Insert(4, 4); next.X < r.X+r.Width+4 && next.Y < r.Y+r.Height+4 {
+		t.Errorf("%v lies in the padding of %v", next, r)
+	}
+}
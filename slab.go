@@ -0,0 +1,113 @@
+package binpacker
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// SlabPacker speeds up packing many rectangles of one common size. It reserves
+// slabs, regions of columns x rows cells of that size, in an underlying Packer
+// and hands out their cells from a bit set. Cells can be inserted and removed
+// in constant time per slab. Rectangles of any other size are inserted into
+// the underlying Packer directly.
+type SlabPacker struct {
+	packer        *Packer
+	cellW, cellH  int
+	columns, rows int
+	slabs         []slab
+	nonFull       []int // indices of slabs with free cells
+}
+
+type slab struct {
+	Rect
+	free      []uint64 // bit i is set if cell i is free
+	freeCount int
+}
+
+// NewSlabPacker returns a SlabPacker that puts its slabs and all rectangles
+// that are not of size cellWidth x cellHeight into p. All sizes must be
+// positive. Slabs are inserted into p like any other rectangle, so p's padding
+// applies around each slab but not between the cells of a slab.
+func NewSlabPacker(p *Packer, cellWidth, cellHeight, columns, rows int) (*SlabPacker, error) {
+	if cellWidth <= 0 || cellHeight <= 0 || columns <= 0 || rows <= 0 {
+		return nil, errors.New("new slab packer: cell size, columns and rows must be positive")
+	}
+	return &SlabPacker{
+		packer:  p,
+		cellW:   cellWidth,
+		cellH:   cellHeight,
+		columns: columns,
+		rows:    rows,
+	}, nil
+}
+
+// Insert places a rectangle in a free slab cell if it has the cell size, or in
+// the underlying Packer otherwise. Neighboring cells touch without padding.
+func (s *SlabPacker) Insert(width, height int) (Rect, error) {
+	if width != s.cellW || height != s.cellH {
+		return s.packer.Insert(width, height)
+	}
+
+	if len(s.nonFull) == 0 {
+		r, err := s.packer.Insert(s.columns*s.cellW, s.rows*s.cellH)
+		if err != nil {
+			return Rect{}, err
+		}
+		cells := s.columns * s.rows
+		free := make([]uint64, (cells+63)/64)
+		for i := 0; i < cells; i++ {
+			free[i/64] |= 1 << uint(i%64)
+		}
+		s.slabs = append(s.slabs, slab{Rect: r, free: free, freeCount: cells})
+		s.nonFull = append(s.nonFull, len(s.slabs)-1)
+	}
+
+	index := s.nonFull[len(s.nonFull)-1]
+	sl := &s.slabs[index]
+	cell := 0
+	for i, word := range sl.free {
+		if word != 0 {
+			cell = i*64 + bits.TrailingZeros64(word)
+			break
+		}
+	}
+	sl.free[cell/64] &^= 1 << uint(cell%64)
+	sl.freeCount--
+	if sl.freeCount == 0 {
+		s.nonFull = s.nonFull[:len(s.nonFull)-1]
+	}
+	return Rect{
+		X:      sl.X + cell%s.columns*s.cellW,
+		Y:      sl.Y + cell/s.columns*s.cellH,
+		Width:  s.cellW,
+		Height: s.cellH,
+	}, nil
+}
+
+// Remove frees a slab cell returned by Insert. Rectangles of other sizes live in
+// the underlying Packer which does not support removal.
+func (s *SlabPacker) Remove(r Rect) error {
+	if r.Width != s.cellW || r.Height != s.cellH {
+		return errors.New("remove: only slab cells can be removed")
+	}
+	for index := range s.slabs {
+		sl := &s.slabs[index]
+		dx, dy := r.X-sl.X, r.Y-sl.Y
+		if dx < 0 || dy < 0 || dx >= sl.Width || dy >= sl.Height ||
+			dx%s.cellW != 0 || dy%s.cellH != 0 {
+			continue
+		}
+		cell := dy/s.cellH*s.columns + dx/s.cellW
+		mask := uint64(1) << uint(cell%64)
+		if sl.free[cell/64]&mask != 0 {
+			break // cell is not in use
+		}
+		sl.free[cell/64] |= mask
+		if sl.freeCount == 0 {
+			s.nonFull = append(s.nonFull, index)
+		}
+		sl.freeCount++
+		return nil
+	}
+	return errors.New("remove: rect was not inserted")
+}
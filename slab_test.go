@@ -0,0 +1,55 @@
+package binpacker
+
+import "testing"
+
+func TestSlabPackerReusesRemovedCells(t *testing.T) {
+	s, _ := NewSlabPacker(New(100, 100), 10, 10, 2, 2)
+	var cells []Rect
+	for i := 0; i < 5; i++ {
+		r, err := s.Insert(10, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cells = append(cells, r)
+	}
+	if len(s.slabs) != 2 {
+		t.Errorf("want 2 slabs but have %d", len(s.slabs))
+	}
+	if cells[1] != (Rect{10, 0, 10, 10}) || cells[2] != (Rect{0, 10, 10, 10}) {
+		t.Errorf("unexpected cells %v", cells)
+	}
+
+	if err := s.Remove(cells[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(cells[1]); err == nil {
+		t.Error("removing twice should fail")
+	}
+	r, _ := s.Insert(10, 10)
+	if r != cells[1] {
+		t.Errorf("want freed cell %v but have %v", cells[1], r)
+	}
+}
+
+func TestSlabPackerFallsBackForOtherSizes(t *testing.T) {
+	p := New(100, 100)
+	s, _ := NewSlabPacker(p, 10, 10, 2, 2)
+	r, err := s.Insert(30, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.slabs) != 0 || p.Occupancy() != 150/10000.0 {
+		t.Errorf("%v was not inserted into the general packer", r)
+	}
+	if err := s.Remove(r); err == nil {
+		t.Error("want error when removing a non-slab rect")
+	}
+}
+
+func TestNewSlabPackerRejectsEmptySlabs(t *testing.T) {
+	for _, size := range [][4]int{{10, 10, 0, 2}, {10, 10, 2, 0}, {0, 10, 2, 2}, {10, -1, 2, 2}} {
+		if _, err := NewSlabPacker(New(100, 100), size[0], size[1], size[2], size[3]); err == nil {
+			t.Errorf("%v: want error", size)
+		}
+	}
+}
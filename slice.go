@@ -0,0 +1,63 @@
+package binpacker
+
+import (
+	"image"
+	"image/color"
+)
+
+// FindSprites detects the sprites of an existing sprite sheet and returns
+// their bounding boxes, e.g. to repack a legacy sheet. A sprite is a group of
+// connected non-background pixels, including diagonal neighbors. If background
+// is nil, fully transparent pixels are background, otherwise background can
+// test for a color key. The rects are in image coordinates, ordered by their
+// top-most, then left-most pixel, and can be used with SubImage to cut out the
+// sprites.
+func FindSprites(img image.Image, background func(color.Color) bool) []Rect {
+	if background == nil {
+		background = func(c color.Color) bool {
+			_, _, _, a := c.RGBA()
+			return a == 0
+		}
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	seen := make([]bool, w*h)
+	isSprite := func(x, y int) bool {
+		return x >= 0 && y >= 0 && x < w && y < h && !seen[y*w+x] &&
+			!background(img.At(b.Min.X+x, b.Min.Y+y))
+	}
+
+	var sprites []Rect
+	var stack []image.Point
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !isSprite(x, y) {
+				continue
+			}
+			minX, minY, maxX, maxY := x, y, x, y
+			seen[y*w+x] = true
+			stack = append(stack[:0], image.Pt(x, y))
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				minX, minY = min(minX, p.X), min(minY, p.Y)
+				maxX, maxY = max(maxX, p.X), max(maxY, p.Y)
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						if isSprite(p.X+dx, p.Y+dy) {
+							seen[(p.Y+dy)*w+p.X+dx] = true
+							stack = append(stack, image.Pt(p.X+dx, p.Y+dy))
+						}
+					}
+				}
+			}
+			sprites = append(sprites, Rect{
+				X:      b.Min.X + minX,
+				Y:      b.Min.Y + minY,
+				Width:  maxX - minX + 1,
+				Height: maxY - minY + 1,
+			})
+		}
+	}
+	return sprites
+}
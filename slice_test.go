@@ -0,0 +1,39 @@
+package binpacker
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestFindSprites(t *testing.T) {
+	img := image.NewRGBA(image.Rect(10, 10, 20, 18))
+	opaque := color.RGBA{255, 0, 0, 255}
+	// an L shape with a diagonal pixel attached
+	for _, p := range []image.Point{{11, 11}, {11, 12}, {12, 12}, {13, 13}} {
+		img.Set(p.X, p.Y, opaque)
+	}
+	// a separate 2x2 block
+	for _, p := range []image.Point{{17, 15}, {18, 15}, {17, 16}, {18, 16}} {
+		img.Set(p.X, p.Y, opaque)
+	}
+
+	want := []Rect{{11, 11, 3, 3}, {17, 15, 2, 2}}
+	if have := FindSprites(img, nil); !reflect.DeepEqual(have, want) {
+		t.Errorf("want %v but have %v", want, have)
+	}
+
+	magenta := color.RGBA{255, 0, 255, 255}
+	key := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			key.Set(x, y, magenta)
+		}
+	}
+	key.Set(2, 1, opaque)
+	isKey := func(c color.Color) bool { return c == color.Color(magenta) }
+	if have := FindSprites(key, isKey); len(have) != 1 || have[0] != (Rect{2, 1, 1, 1}) {
+		t.Errorf("color key: %v", have)
+	}
+}
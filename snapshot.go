@@ -0,0 +1,84 @@
+package binpacker
+
+import "math"
+
+// Layout is an immutable view of a packer's state at one point in time.
+type Layout struct {
+	Width, Height int
+	// Rects are all inserted rectangles in insertion order. The slice may be
+	// shared between snapshots and must not be modified.
+	Rects []Rect
+}
+
+// Snapshot returns the packer's current layout. It is safe to call Snapshot
+// from any goroutine, even while another goroutine modifies the packer,
+// without holding the writer's lock. The returned Layout never changes.
+func (p *Packer) Snapshot() Layout {
+	return *p.snapshot.Load().(*Layout)
+}
+
+// publish makes the current state visible to Snapshot. It must be called after
+// every change to the bin size or the inserted rectangles.
+func (p *Packer) publish() {
+	rects := p.rects[:len(p.rects):len(p.rects)]
+	if p.yUp {
+		// Flipped rects depend on the bin height so they cannot be shared.
+		rects = make([]Rect, len(p.rects))
+		for i, r := range p.rects {
+			rects[i] = p.flip(r)
+		}
+	}
+	p.snapshot.Store(&Layout{
+		Width:  p.binWidth,
+		Height: p.binHeight,
+		Rects:  rects,
+	})
+}
+
+// Scale returns a copy of the layout with the bin and all rectangles scaled by
+// the given factor, e.g. to retarget it to a texture of twice the resolution.
+// Rectangle edges are rounded to the nearest integer, so rectangles that touch
+// before scaling still touch afterwards.
+func (l Layout) Scale(factor float64) Layout {
+	scale := func(x int) int { return int(math.Round(float64(x) * factor)) }
+	return l.mapRects(scale(l.Width), scale(l.Height), func(r Rect) Rect {
+		x, y := scale(r.X), scale(r.Y)
+		return Rect{X: x, Y: y, Width: scale(r.X+r.Width) - x, Height: scale(r.Y+r.Height) - y}
+	})
+}
+
+// Offset returns a copy of the layout with all rectangles moved by dx and dy.
+// The bin size stays the same.
+func (l Layout) Offset(dx, dy int) Layout {
+	return l.mapRects(l.Width, l.Height, func(r Rect) Rect {
+		r.X += dx
+		r.Y += dy
+		return r
+	})
+}
+
+// FlipVertical returns a copy of the layout mirrored at the bin's horizontal
+// center line, e.g. to convert between Y-down and Y-up coordinates.
+func (l Layout) FlipVertical() Layout {
+	return l.mapRects(l.Width, l.Height, func(r Rect) Rect {
+		r.Y = l.Height - r.Y - r.Height
+		return r
+	})
+}
+
+// FlipHorizontal returns a copy of the layout mirrored at the bin's vertical
+// center line.
+func (l Layout) FlipHorizontal() Layout {
+	return l.mapRects(l.Width, l.Height, func(r Rect) Rect {
+		r.X = l.Width - r.X - r.Width
+		return r
+	})
+}
+
+func (l Layout) mapRects(width, height int, f func(Rect) Rect) Layout {
+	rects := make([]Rect, len(l.Rects))
+	for i, r := range l.Rects {
+		rects[i] = f(r)
+	}
+	return Layout{Width: width, Height: height, Rects: rects}
+}
@@ -0,0 +1,56 @@
+package binpacker
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSnapshotIsStableWhileInserting(t *testing.T) {
+	p := New(100, 100)
+	first, _ := p.Insert(10, 10)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			s := p.Snapshot()
+			if len(s.Rects) == 0 || s.Rects[0] != first {
+				t.Errorf("unexpected snapshot %v", s)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		p.InsertGrow(10, 10)
+	}
+	wg.Wait()
+
+	s := p.Snapshot()
+	if len(s.Rects) != 51 || s.Width != p.binWidth || s.Height != p.binHeight {
+		t.Errorf("final snapshot has %d rects and size %dx%d",
+			len(s.Rects), s.Width, s.Height)
+	}
+}
+
+func TestLayoutTransforms(t *testing.T) {
+	l := Layout{Width: 10, Height: 6, Rects: []Rect{{0, 0, 3, 3}, {3, 0, 3, 2}}}
+
+	s := l.Scale(1.5)
+	if s.Width != 15 || s.Height != 9 ||
+		s.Rects[0] != (Rect{0, 0, 5, 5}) || s.Rects[1] != (Rect{5, 0, 4, 3}) {
+		t.Errorf("Scale: %v", s)
+	}
+	if o := l.Offset(1, 2); o.Rects[1] != (Rect{4, 2, 3, 2}) || o.Width != 10 {
+		t.Errorf("Offset: %v", o)
+	}
+	if v := l.FlipVertical(); v.Rects[1] != (Rect{3, 4, 3, 2}) {
+		t.Errorf("FlipVertical: %v", v)
+	}
+	if h := l.FlipHorizontal(); h.Rects[1] != (Rect{4, 0, 3, 2}) {
+		t.Errorf("FlipHorizontal: %v", h)
+	}
+	if l.Rects[1] != (Rect{3, 0, 3, 2}) {
+		t.Error("original layout was modified")
+	}
+}
@@ -0,0 +1,95 @@
+package binpacker
+
+import "errors"
+
+// SetSpacing requires rectangles tagged a and rectangles tagged b to be at
+// least distance apart, horizontally or vertically, e.g. to keep emissive
+// sprites from blooming into each other with SetSpacing("glow", "glow", 8).
+// The distance is measured between the rectangles themselves, padding counts
+// towards it. Setting a distance for the same pair again replaces it, a
+// distance of 0 removes it.
+//
+// Only InsertTagged keeps the spacing. It does so to all rectangles in the
+// bin, including those that were tagged with Tag after being inserted.
+func (p *Packer) SetSpacing(a, b string, distance int) error {
+	if distance < 0 {
+		return errors.New("spacing: negative distance")
+	}
+	if err := p.writeLogText("spacing", []int{distance}, a, b); err != nil {
+		return err
+	}
+	for i, r := range p.spacing {
+		if r.a == a && r.b == b || r.a == b && r.b == a {
+			p.spacing = append(p.spacing[:i], p.spacing[i+1:]...)
+			break
+		}
+	}
+	if distance > 0 {
+		p.spacing = append(p.spacing, spacingRule{a: a, b: b, distance: distance})
+	}
+	return nil
+}
+
+type spacingRule struct {
+	a, b     string
+	distance int
+}
+
+// InsertTagged works like Insert but tags the new rectangle, see Tag, and
+// places it so that it keeps the distances set with SetSpacing to the
+// rectangles already in the bin. Like Insert it takes the first free place in
+// which that is possible.
+func (p *Packer) InsertTagged(width, height int, tags ...string) (Rect, error) {
+	if err := p.writeLogText("tagged", []int{width, height}, tags...); err != nil {
+		return Rect{}, err
+	}
+	_, _, padding := p.regionSize(width, height, p.padding)
+	r, err := p.insert(width, height, p.padding, func(w, h int) (*node, bool, bool) {
+		return findFreeWhere(&p.root, w, h, func(region Rect) bool {
+			r := Rect{X: region.X + padding, Y: region.Y + padding, Width: width, Height: height}
+			return !hitsDefect(region, p.defects) && !p.tooClose(r, tags)
+		})
+	})
+	if err != nil {
+		return Rect{}, err
+	}
+	p.items[len(p.items)-1].tags = append([]string(nil), tags...)
+	return r, nil
+}
+
+// tooClose tells whether r, with the given tags, would be closer to any item
+// than their tags allow.
+func (p *Packer) tooClose(r Rect, tags []string) bool {
+	if len(p.spacing) == 0 {
+		return false
+	}
+	for _, it := range p.items {
+		d := p.requiredSpacing(tags, it.tags)
+		if d > 0 && it.Intersects(Rect{X: r.X - d, Y: r.Y - d, Width: r.Width + 2*d, Height: r.Height + 2*d}) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredSpacing returns the largest distance that the rules require between
+// rectangles with the tags a and b.
+func (p *Packer) requiredSpacing(a, b []string) int {
+	d := 0
+	for _, rule := range p.spacing {
+		if rule.distance > d &&
+			(hasTag(a, rule.a) && hasTag(b, rule.b) || hasTag(a, rule.b) && hasTag(b, rule.a)) {
+			d = rule.distance
+		}
+	}
+	return d
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package binpacker
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestInsertTaggedKeepsSpacing(t *testing.T) {
+	p := New(30, 10)
+	p.SetSpacing("glow", "glow", 5)
+
+	a, _ := p.InsertTagged(5, 5, "glow")
+	b, _ := p.InsertTagged(5, 5, "glow")
+	if a != (Rect{0, 0, 5, 5}) || b != (Rect{25, 0, 5, 5}) {
+		t.Errorf("glowing rects are at %v and %v", a, b)
+	}
+	// there is no rule for other tags
+	if c, _ := p.InsertTagged(5, 5, "plain"); c != (Rect{0, 5, 5, 5}) {
+		t.Errorf("plain rect is at %v", c)
+	}
+	if have := p.ItemsWithTag("glow"); !reflect.DeepEqual(have, []Rect{a, b}) {
+		t.Errorf("tagged items are %v", have)
+	}
+	if _, err := p.InsertTagged(5, 5, "glow"); err != ErrNoMoreSpace {
+		t.Errorf("want ErrNoMoreSpace but have %v", err)
+	}
+	if _, err := p.Insert(5, 5); err != nil {
+		t.Errorf("Insert ignores the spacing but failed: %v", err)
+	}
+}
+
+func TestSetSpacingReplacesRules(t *testing.T) {
+	p := New(10, 10)
+	p.SetSpacing("a", "b", 5)
+	p.SetSpacing("b", "a", 2)
+	if d := p.requiredSpacing([]string{"a"}, []string{"b"}); d != 2 {
+		t.Errorf("spacing is %d", d)
+	}
+	p.SetSpacing("a", "b", 0)
+	if d := p.requiredSpacing([]string{"a"}, []string{"b"}); d != 0 {
+		t.Errorf("spacing is %d after removing it", d)
+	}
+	if err := p.SetSpacing("a", "b", -1); err == nil {
+		t.Error("want error for negative distance")
+	}
+}
+
+func TestRecoverReplaysSpacing(t *testing.T) {
+	var log bytes.Buffer
+	p, _ := NewLogged(30, 10, &log)
+	p.SetSpacing("hot part", `"cold"`, 5)
+	p.InsertTagged(5, 5, "hot part")
+	p.InsertTagged(5, 5, `"cold"`)
+
+	q, err := Recover(bytes.NewReader(log.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(q.Snapshot(), p.Snapshot()) {
+		t.Errorf("want %v but have %v", p.Snapshot(), q.Snapshot())
+	}
+	if have := q.ItemsWithTag(`"cold"`); len(have) != 1 || have[0] != (Rect{25, 0, 5, 5}) {
+		t.Errorf("recovered tagged items are %v", have)
+	}
+}
@@ -0,0 +1,35 @@
+package binpacker
+
+import "errors"
+
+// Tag attaches tags to a rectangle that was returned by Insert.
+func (p *Packer) Tag(r Rect, tags ...string) error {
+	r = p.flip(r)
+	for i := range p.items {
+		if p.items[i].Rect == r {
+			for _, t := range tags {
+				if err := p.writeLogText("tag", []int{i}, t); err != nil {
+					return err
+				}
+				p.items[i].tags = append(p.items[i].tags, t)
+			}
+			return nil
+		}
+	}
+	return errors.New("tag: rect was not inserted")
+}
+
+// ItemsWithTag returns all inserted rectangles that have the given tag, in
+// insertion order.
+func (p *Packer) ItemsWithTag(tag string) []Rect {
+	var rects []Rect
+	for _, it := range p.items {
+		for _, t := range it.tags {
+			if t == tag {
+				rects = append(rects, p.flip(it.Rect))
+				break
+			}
+		}
+	}
+	return rects
+}
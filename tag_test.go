@@ -0,0 +1,28 @@
+package binpacker
+
+import "testing"
+
+func TestItemsWithTag(t *testing.T) {
+	p := New(100, 100)
+	a, _ := p.Insert(10, 10)
+	b, _ := p.Insert(20, 20)
+	c, _ := p.Insert(30, 30)
+	p.Tag(a, "ui")
+	p.Tag(b, "world")
+	p.Tag(c, "ui", "big")
+	if err := p.Tag(Rect{1, 2, 3, 4}, "ui"); err == nil {
+		t.Error("want error for unknown rect")
+	}
+
+	mapping, err := p.ShrinkTo(60, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui := p.ItemsWithTag("ui")
+	if len(ui) != 2 || ui[0] != mapping[a] || ui[1] != mapping[c] {
+		t.Errorf("unexpected ui items %v", ui)
+	}
+	if len(p.ItemsWithTag("nothing")) != 0 {
+		t.Error("unknown tag has items")
+	}
+}
@@ -0,0 +1,59 @@
+package binpacker
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+)
+
+// Template describes a bin with fixed, pre-placed regions, e.g. the
+// hand-authored core of a UI atlas. In JSON the regions map keys to rects in
+// the form "x,y,wxh":
+//
+//	{"Width": 256, "Height": 256, "Regions": {"button": "0,0,64x32"}}
+type Template struct {
+	Width, Height int
+	Regions       map[string]Rect
+}
+
+// LoadTemplate reads a Template from JSON.
+func LoadTemplate(r io.Reader) (Template, error) {
+	var t Template
+	err := json.NewDecoder(r).Decode(&t)
+	return t, err
+}
+
+// NewFromTemplate creates a packer with the template's regions already
+// inserted and tagged with their keys. The rest of the bin is free for
+// inserts. Since the packer cuts its free space into rectangles, the regions
+// must be placeable with guillotine cuts, starting in the bin's corners. This
+// is true for layouts created by a Packer and for most grid-like layouts.
+func NewFromTemplate(t Template) (*Packer, error) {
+	p := New(t.Width, t.Height)
+	keys := make([]string, 0, len(t.Regions))
+	for key := range t.Regions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// A region might only lie in the corner of a free area after some other
+	// region was placed, so keep trying until no more regions can be placed.
+	for len(keys) > 0 {
+		var rest []string
+		for _, key := range keys {
+			r, err := p.insertAt(t.Regions[key])
+			if err != nil {
+				rest = append(rest, key)
+				continue
+			}
+			p.Tag(r, key)
+		}
+		if len(rest) == len(keys) {
+			return nil, errors.New("template: region " + rest[0] + " cannot be placed")
+		}
+		keys = rest
+	}
+	p.failures, p.failStreak = 0, 0
+	return p, nil
+}
@@ -0,0 +1,41 @@
+package binpacker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewFromTemplate(t *testing.T) {
+	tmpl, err := LoadTemplate(strings.NewReader(`{
+		"Width": 100, "Height": 100,
+		"Regions": {
+			"b": "20,0,20x20",
+			"a": "0,0,20x20",
+			"c": "0,20,20x10"
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewFromTemplate(tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := p.ItemsWithTag("b"); len(b) != 1 || b[0] != (Rect{20, 0, 20, 20}) {
+		t.Errorf("region b is at %v", b)
+	}
+	if s := p.Stats(); s.Inserts != 3 || s.Failures != 0 {
+		t.Errorf("stats are %+v", s)
+	}
+	r, _ := p.Insert(10, 10)
+	for _, fixed := range tmpl.Regions {
+		if r.Intersects(fixed) {
+			t.Errorf("insert at %v overlaps template region %v", r, fixed)
+		}
+	}
+
+	tmpl.Regions["d"] = Rect{50, 50, 10, 10}
+	if _, err := NewFromTemplate(tmpl); err == nil {
+		t.Error("region in the middle of free space should not be placeable")
+	}
+}
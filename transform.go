@@ -0,0 +1,77 @@
+package binpacker
+
+// SetOrigin moves the bin so that its top-left corner is at (x, y) instead of
+// (0, 0). All rectangles returned by the packer, including the ones that were
+// inserted before, are relative to this origin, and all rectangles passed to
+// the packer are expected in the same coordinates.
+func (p *Packer) SetOrigin(x, y int) error {
+	if err := p.writeLog("origin", x, y); err != nil {
+		return err
+	}
+	dx, dy := x-p.originX, y-p.originY
+	p.originX, p.originY = x, y
+	p.transform(func(r Rect) Rect {
+		r.X += dx
+		r.Y += dy
+		return r
+	})
+	return nil
+}
+
+// SetYUp selects the vertical coordinate convention. By default Y points down
+// and rectangles are given by their top-left corner, like in images. With
+// Y-up, as in OpenGL, Y points up from the bottom of the bin and rectangles
+// are given by their bottom-left corner. This applies to all rectangles
+// returned by or passed to the packer, and thus to UV as well. Since Y-up
+// coordinates depend on the bin height, Enlarge and ShrinkTo change the
+// coordinates of all previously inserted rectangles. Every Snapshot in Y-up
+// mode copies the rectangles.
+func (p *Packer) SetYUp(up bool) error {
+	flag := 0
+	if up {
+		flag = 1
+	}
+	if err := p.writeLog("yup", flag); err != nil {
+		return err
+	}
+	p.yUp = up
+	p.publish()
+	return nil
+}
+
+// flip converts r between the internal Y-down and the Y-up convention if the
+// packer is set to Y-up. The conversion is its own inverse.
+func (p *Packer) flip(r Rect) Rect {
+	if p.yUp {
+		r.Y = 2*p.originY + p.binHeight - r.Y - r.Height
+	}
+	return r
+}
+
+// transform applies f to every rectangle that the packer stores: the regions
+// of the tree, the inserted items and the defects.
+func (p *Packer) transform(f func(Rect) Rect) {
+	var transformNode func(n *node)
+	transformNode = func(n *node) {
+		n.Rect = f(n.Rect)
+		if n.left != nil {
+			transformNode(n.left)
+		}
+		if n.right != nil {
+			transformNode(n.right)
+		}
+	}
+	transformNode(&p.root)
+
+	for i := range p.defects {
+		p.defects[i] = f(p.defects[i])
+	}
+
+	p.rects = make([]Rect, len(p.items))
+	for i := range p.items {
+		p.items[i].Rect = f(p.items[i].Rect)
+		p.items[i].region = f(p.items[i].region)
+		p.rects[i] = p.items[i].Rect
+	}
+	p.publish()
+}
@@ -0,0 +1,75 @@
+package binpacker
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetOriginOffsetsAllRects(t *testing.T) {
+	p := New(100, 100)
+	before, _ := p.Insert(10, 10)
+	p.SetOrigin(64, 64)
+	if s := p.Snapshot(); s.Rects[0] != (Rect{64, 64, 10, 10}) {
+		t.Errorf("existing rect %v was not moved: %v", before, s.Rects[0])
+	}
+
+	p.Enlarge(200, 200)
+	r, _ := p.Insert(10, 10)
+	if r != (Rect{64, 164, 10, 10}) {
+		t.Errorf("unexpected placement %v", r)
+	}
+	if found, ok := p.At(70, 170); !ok || found != r {
+		t.Errorf("At did not find %v", r)
+	}
+	if u0, v0, _, _ := p.UV(r); u0 != 0 || v0 != 0.5 {
+		t.Errorf("unexpected UV %v %v", u0, v0)
+	}
+}
+
+func TestYUpFlipsAllRects(t *testing.T) {
+	p := New(100, 100)
+	p.SetYUp(true)
+	r, _ := p.Insert(10, 20)
+	if r != (Rect{0, 80, 10, 20}) {
+		t.Errorf("want rect at the top in Y-up coordinates but have %v", r)
+	}
+	if found, ok := p.At(5, 95); !ok || found != r {
+		t.Errorf("At did not find %v", r)
+	}
+	if items := p.ItemsIn(Rect{0, 90, 1, 1}); len(items) != 1 || items[0] != r {
+		t.Errorf("ItemsIn did not find %v", r)
+	}
+	if s := p.Snapshot(); s.Rects[0] != r {
+		t.Errorf("snapshot has %v instead of %v", s.Rects[0], r)
+	}
+	if _, v0, _, v1 := p.UV(r); v0 != 0.8 || v1 != 1 {
+		t.Errorf("unexpected UV %v %v", v0, v1)
+	}
+
+	mapping, err := p.ShrinkTo(50, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping[r] != (Rect{0, 30, 10, 20}) {
+		t.Errorf("unexpected mapping %v", mapping)
+	}
+}
+
+func TestRecoverReplaysYUp(t *testing.T) {
+	var log bytes.Buffer
+	p, _ := NewLogged(100, 100, &log)
+	p.SetYUp(true)
+	p.AddDefects(Rect{0, 90, 10, 10}) // top-left corner in Y-up
+	r, _ := p.Preview(10, 10)
+	if err := p.Commit(r); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := Recover(bytes.NewReader(log.Bytes()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := q.Snapshot().Rects, p.Snapshot().Rects; len(have) != 1 || have[0] != want[0] {
+		t.Errorf("recovered %v, want %v", have, want)
+	}
+}
@@ -0,0 +1,56 @@
+package binpacker
+
+import "errors"
+
+// Trim removes strips of the given widths from the borders of an empty bin,
+// e.g. the rough edges of a raw sheet. Unlike padding, the trim only applies
+// to the outside of the bin. Trimmed strips are neither used nor free, and
+// CutList starts with the trim cuts. The trim is kept by ShrinkTo but Enlarge
+// adds untrimmed space.
+func (p *Packer) Trim(left, top, right, bottom int) error {
+	if p.root.left != nil || p.root.right != nil {
+		return errors.New("trim: bin is not empty")
+	}
+	if left+right > p.binWidth || top+bottom > p.binHeight {
+		return errors.New("trim: trim is larger than the bin")
+	}
+	if err := p.writeLog("trim", left, top, right, bottom); err != nil {
+		return err
+	}
+	p.trim = [4]int{left, top, right, bottom}
+	p.root.Rect = p.trimmed(p.binWidth, p.binHeight)
+	return nil
+}
+
+// trimmed returns the usable area of a bin of the given size.
+func (p *Packer) trimmed(width, height int) Rect {
+	left, top, right, bottom := p.trim[0], p.trim[1], p.trim[2], p.trim[3]
+	return Rect{
+		X:      p.originX + left,
+		Y:      p.originY + top,
+		Width:  width - left - right,
+		Height: height - top - bottom,
+	}
+}
+
+// trimCuts returns the cuts that remove the trimmed borders, full width cuts
+// first.
+func (p *Packer) trimCuts() []Cut {
+	left, top, right, bottom := p.trim[0], p.trim[1], p.trim[2], p.trim[3]
+	x, y, w, h := p.originX, p.originY, p.binWidth, p.binHeight
+	var cuts []Cut
+	if top > 0 {
+		cuts = append(cuts, Cut{X: x, Y: y + top, Length: w, Horizontal: true})
+	}
+	if bottom > 0 {
+		cuts = append(cuts, Cut{X: x, Y: y + h - bottom, Length: w, Horizontal: true})
+	}
+	inner := h - top - bottom
+	if left > 0 {
+		cuts = append(cuts, Cut{X: x + left, Y: y + top, Length: inner})
+	}
+	if right > 0 {
+		cuts = append(cuts, Cut{X: x + w - right, Y: y + top, Length: inner})
+	}
+	return cuts
+}
@@ -0,0 +1,28 @@
+package binpacker
+
+import "testing"
+
+func TestTrim(t *testing.T) {
+	p := New(100, 50)
+	if err := p.Trim(1, 2, 3, 4); err != nil {
+		t.Fatal(err)
+	}
+	r, _ := p.Insert(96, 10)
+	if r != (Rect{1, 2, 96, 10}) {
+		t.Errorf("unexpected placement %v", r)
+	}
+	if _, err := p.Insert(97, 1); err != ErrNoMoreSpace {
+		t.Errorf("trimmed border was used: %v", err)
+	}
+	if err := p.Trim(0, 0, 0, 0); err == nil {
+		t.Error("want error when trimming a non-empty bin")
+	}
+
+	cuts, _ := p.CutList()
+	checkCuts(t, cuts[:4], []Cut{
+		{X: 0, Y: 2, Length: 100, Horizontal: true},
+		{X: 0, Y: 46, Length: 100, Horizontal: true},
+		{X: 1, Y: 2, Length: 44},
+		{X: 97, Y: 2, Length: 44},
+	})
+}
@@ -0,0 +1,25 @@
+package binpacker
+
+// HalfTexel is the usual UV inset that keeps bilinear filtering from sampling
+// neighboring texels, see SetUVInset.
+const HalfTexel = 0.5
+
+// UV converts r to normalized texture coordinates of the bin, with (0,0) being
+// the top-left and (1,1) the bottom-right corner of the bin. r is relative to
+// the packer's origin, like all rects returned by the packer. The coordinates
+// are moved inwards by the inset set with SetUVInset.
+func (p *Packer) UV(r Rect) (u0, v0, u1, v1 float64) {
+	w, h := float64(p.binWidth), float64(p.binHeight)
+	inset := p.uvInset
+	x, y := r.X-p.originX, r.Y-p.originY
+	return (float64(x) + inset) / w,
+		(float64(y) + inset) / h,
+		(float64(x+r.Width) - inset) / w,
+		(float64(y+r.Height) - inset) / h
+}
+
+// SetUVInset makes UV move all texture coordinates inwards by the given number
+// of texels on each side, e.g. HalfTexel. The default is 0.
+func (p *Packer) SetUVInset(texels float64) {
+	p.uvInset = texels
+}
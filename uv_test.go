@@ -0,0 +1,20 @@
+package binpacker
+
+import "testing"
+
+func TestUV(t *testing.T) {
+	p := New(200, 100)
+	u0, v0, u1, v1 := p.UV(Rect{X: 50, Y: 25, Width: 100, Height: 50})
+	if u0 != 0.25 || v0 != 0.25 || u1 != 0.75 || v1 != 0.75 {
+		t.Errorf("unexpected UVs %v %v %v %v", u0, v0, u1, v1)
+	}
+}
+
+func TestUVInset(t *testing.T) {
+	p := New(100, 10)
+	p.SetUVInset(HalfTexel)
+	u0, v0, u1, v1 := p.UV(Rect{X: 0, Y: 0, Width: 10, Height: 10})
+	if u0 != 0.005 || v0 != 0.05 || u1 != 0.095 || v1 != 0.95 {
+		t.Errorf("unexpected UVs %v %v %v %v", u0, v0, u1, v1)
+	}
+}
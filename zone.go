@@ -0,0 +1,65 @@
+package binpacker
+
+import "errors"
+
+// Zones partitions a bin into named, non-overlapping zones. Each zone is a
+// Packer of its own, positioned with SetOrigin, so all zones share the bin's
+// coordinates and each can have its own padding, alignment and so on.
+type Zones struct {
+	width, height int
+	names         []string
+	zones         map[string]*Packer
+}
+
+// NewZones creates an empty partitioning of a bin of the given size.
+func NewZones(width, height int) *Zones {
+	return &Zones{width: width, height: height, zones: make(map[string]*Packer)}
+}
+
+// Add creates a zone covering r and returns its packer for configuration.
+// The zone must lie inside the bin and must not overlap other zones. Do not
+// enlarge a zone's packer, it would grow into its neighbors.
+func (z *Zones) Add(name string, r Rect) (*Packer, error) {
+	if _, ok := z.zones[name]; ok {
+		return nil, errors.New("add zone: name is already used")
+	}
+	if r.X < 0 || r.Y < 0 || r.Width <= 0 || r.Height <= 0 ||
+		r.X+r.Width > z.width || r.Y+r.Height > z.height {
+		return nil, errors.New("add zone: zone is not inside the bin")
+	}
+	for _, other := range z.names {
+		p := z.zones[other]
+		if r.Intersects(Rect{X: p.originX, Y: p.originY, Width: p.binWidth, Height: p.binHeight}) {
+			return nil, errors.New("add zone: zone overlaps " + other)
+		}
+	}
+	p := New(r.Width, r.Height)
+	p.SetOrigin(r.X, r.Y)
+	z.names = append(z.names, name)
+	z.zones[name] = p
+	return p, nil
+}
+
+// Zone returns the packer of the named zone or nil if there is no such zone.
+func (z *Zones) Zone(name string) *Packer {
+	return z.zones[name]
+}
+
+// Insert places a rectangle in the named zone, see Packer.Insert.
+func (z *Zones) Insert(zone string, width, height int) (Rect, error) {
+	p, ok := z.zones[zone]
+	if !ok {
+		return Rect{}, errors.New("insert: unknown zone " + zone)
+	}
+	return p.Insert(width, height)
+}
+
+// Snapshot returns the layout of the whole bin. Its rectangles are those of
+// all zones, zone by zone in the order the zones were added.
+func (z *Zones) Snapshot() Layout {
+	l := Layout{Width: z.width, Height: z.height}
+	for _, name := range z.names {
+		l.Rects = append(l.Rects, z.zones[name].Snapshot().Rects...)
+	}
+	return l
+}
@@ -0,0 +1,37 @@
+package binpacker
+
+import "testing"
+
+func TestZones(t *testing.T) {
+	z := NewZones(100, 100)
+	ui, err := z.Add("ui", Rect{0, 0, 100, 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.SetPadding(2)
+	if _, err := z.Add("world", Rect{0, 25, 100, 75}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := z.Add("overlap", Rect{50, 20, 10, 10}); err == nil {
+		t.Error("overlapping zone was added")
+	}
+	if _, err := z.Add("outside", Rect{90, 0, 20, 10}); err == nil {
+		t.Error("zone outside the bin was added")
+	}
+
+	if r, _ := z.Insert("ui", 10, 10); r != (Rect{2, 2, 10, 10}) {
+		t.Errorf("ui rect at %v", r)
+	}
+	if r, _ := z.Insert("world", 10, 10); r != (Rect{0, 25, 10, 10}) {
+		t.Errorf("world rect at %v", r)
+	}
+	if _, err := z.Insert("world", 100, 76); err != ErrNoMoreSpace {
+		t.Errorf("rect spilled out of its zone: %v", err)
+	}
+	if _, err := z.Insert("none", 1, 1); err == nil {
+		t.Error("insert into unknown zone should fail")
+	}
+	if n := len(z.Snapshot().Rects); n != 2 {
+		t.Errorf("snapshot has %d rects", n)
+	}
+}